@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ApogeeNetworking/ruckus"
+)
+
+// Exit Code of compliance when a Zone drifted from the Baseline
+const exitDrift = 3
+
+// defaultIgnore the Zone fields that legitimately differ between Zones
+var defaultIgnore = []string{
+	"id",
+	"domainId",
+	"name",
+	"description",
+	"version",
+	"timezone",
+	"login",
+	"location",
+	"locationAdditionalInfo",
+	"latitude",
+	"longitude",
+	"altitude",
+	"awsVenue",
+	"venueProfile",
+}
+
+func complianceCmd(args []string) int {
+	fs := flag.NewFlagSet("compliance", flag.ContinueOnError)
+	baselinePath := fs.String("baseline", "", "baseline zone (JSON as returned by GetZone)")
+	baselineZone := fs.String("baseline-zone", "", "name of a live zone to use as baseline")
+	ignore := fs.String("ignore", strings.Join(defaultIgnore, ","), "comma separated field paths to ignore")
+	format := fs.String("format", "text", "report format (text|json)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if (*baselinePath == "") == (*baselineZone == "") {
+		log.Println("one of -baseline or -baseline-zone is required")
+		return 2
+	}
+	var ignorePaths []string
+	for _, p := range strings.Split(*ignore, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			ignorePaths = append(ignorePaths, p)
+		}
+	}
+	var baseline ruckus.RksZone
+	if *baselinePath != "" {
+		d, err := ioutil.ReadFile(*baselinePath)
+		if err != nil {
+			log.Printf("failed to read baseline: %v", err)
+			return 1
+		}
+		if err := json.Unmarshal(d, &baseline); err != nil {
+			log.Printf("failed to parse baseline: %v", err)
+			return 1
+		}
+	}
+	sz, logout, err := connect()
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer logout()
+	zones, err := sz.GetAllZones(ruckus.RksOptions{})
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	var live []ruckus.RksZone
+	for _, z := range zones {
+		zone, err := sz.GetZone(z.ID)
+		if err != nil {
+			log.Printf("failed to get zone %s: %v", z.Name, err)
+			return 1
+		}
+		if z.Name == *baselineZone {
+			baseline = zone
+		}
+		live = append(live, zone)
+	}
+	if *baselineZone != "" && baseline.ID == "" {
+		log.Printf("baseline zone %s not found", *baselineZone)
+		return 1
+	}
+
+	var report []ruckus.ZoneCompliance
+	drifted := 0
+	for _, zone := range live {
+		if *baselineZone != "" && zone.ID == baseline.ID {
+			continue
+		}
+		zc, err := ruckus.CheckZoneCompliance(zone, baseline, ignorePaths)
+		if err != nil {
+			log.Println(err)
+			return 1
+		}
+		if !zc.Compliant {
+			drifted++
+		}
+		report = append(report, zc)
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else {
+		printCompliance(report, drifted)
+	}
+	if drifted > 0 {
+		return exitDrift
+	}
+	return 0
+}
+
+func printCompliance(report []ruckus.ZoneCompliance, drifted int) {
+	for _, zc := range report {
+		if zc.Compliant {
+			fmt.Printf("OK     %s\n", zc.ZoneName)
+			continue
+		}
+		fmt.Printf("DRIFT  %s (%d fields)\n", zc.ZoneName, len(zc.Diffs))
+		for _, d := range zc.Diffs {
+			fmt.Printf("         %s: baseline %s, zone %s\n", d.Path, jsonString(d.Old), jsonString(d.New))
+		}
+	}
+	fmt.Printf("\n%d of %d zones drifted from the baseline\n", drifted, len(report))
+}
+
+func jsonString(v interface{}) string {
+	d, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(d)
+}
@@ -1,72 +1,123 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"sort"
 
 	"github.com/ApogeeNetworking/ruckus"
 	"github.com/subosito/gotenv"
 )
 
-var host, user, pass string
+var apiVer, host, user, pass string
 
 func init() {
 	gotenv.Load()
+	apiVer = os.Getenv("RKS_API_VER")
 	host = os.Getenv("RKS_HOST")
 	user = os.Getenv("RKS_USER")
 	pass = os.Getenv("RKS_PASS")
 }
 
+// command a Subcommand; it returns the Exit Code of the Process
+type command struct {
+	usage string
+	run   func(args []string) int
+}
+
+var commands = map[string]command{
+	"plan":       {"-f site.yaml [-prune] [-log plan.json]   show the changes apply would make", planCmd},
+	"apply":      {"-f site.yaml [-prune] [-log changes.json]   converge the controller to site.yaml", applyCmd},
+	"compliance": {"-baseline zone.json|-baseline-zone name [-ignore paths] [-format text|json]   report zones drifted from the baseline (exit 3 on drift)", complianceCmd},
+	"snapshot":   {"-o snapshot.tgz|-dir path [-keep-secrets] | diff old.tgz new.tgz   snapshot the controller configuration or compare two snapshots", snapshotCmd},
+	"rf":         {"-zone name [-format table|csv]   report the channel plan of a zone", rfCmd},
+	"aps":        {"   list the APs of the controller", apsCmd},
+	"zones":      {"   list the Zone IDs of the controller", zonesCmd},
+}
+
 func main() {
-	sz := ruckus.New(host, user, pass, true)
-	err := sz.Login()
-	if err != nil {
-		log.Fatalf("login failed: %v", err)
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	os.Exit(cmd.run(os.Args[2:]))
+}
+
+func usage() {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", os.Args[0])
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", name, commands[name].usage)
+	}
+}
+
+// connect creates a Client and logs in; the returned func logs out
+func connect() (*ruckus.Client, func(), error) {
+	sz := ruckus.New(apiVer, host, user, pass, true)
+	if err := sz.Login(); err != nil {
+		return nil, nil, fmt.Errorf("login failed: %v", err)
 	}
-	defer func() {
+	return sz, func() {
 		err := sz.Logout()
 		if err != nil {
 			// Why did we err Logging Out
 			fmt.Println(err)
 		}
+	}, nil
+}
+
+// interruptible returns a Context cancelled on the first interrupt
+func interruptible() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
 	}()
-	type ApChangeREQ struct {
-		MacAddr   string
-		GroupName string
-		ApName    string
-		GroupID   string
-		ZoneID    string
+	return ctx, func() {
+		signal.Stop(sig)
+		cancel()
 	}
-	// apChng := ApChangeREQ{
-	// 	MacAddr:   "60:D0:2C:2A:52:B0",
-	// 	ApName:    "ap01.austin.introom.saml.tx",
-	// 	GroupName: "Dev-Lab-Zone1-Group3",
-	// }
-	// zoneIds := rkszones(sz)
-	// for _, zoneID := range zoneIds {
-	// 	apGroups, _ := sz.GetApGroups(ruckus.RksOptions{}, zoneID)
-	// 	for _, apGroup := range apGroups {
-	// 		if apChng.GroupName == apGroup.Name {
-	// 			apChng.GroupID = apGroup.ID
-	// 			apChng.ZoneID = zoneID
-	// 		}
-	// 	}
-	// }
-	// sz.SetApNameAndGroup(
-	// 	apChng.MacAddr,
-	// 	apChng.ApName,
-	// 	apChng.ZoneID,
-	// 	apChng.GroupID,
-	// )
+}
 
-	// mac := "EC:58:EA:0A:24:D0"
-	mac := "60:d0:2c:2a:52:b0"
-	sz.RebootAp(mac)
-	// sz.GetAp(mac)
-	// apIntf, err := sz.GetApLldp(mac)
-	// fmt.Println(apIntf)
-	// aps(sz)
+func apsCmd(args []string) int {
+	sz, logout, err := connect()
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer logout()
+	aps(sz)
+	return 0
+}
+
+func zonesCmd(args []string) int {
+	sz, logout, err := connect()
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer logout()
+	for _, id := range rkszones(sz) {
+		fmt.Println(id)
+	}
+	return 0
 }
 
 func aps(sz *ruckus.Client) {
@@ -90,10 +141,4 @@ func rkszones(sz *ruckus.Client) []string {
 		zoneIds = append(zoneIds, zone.ID)
 	}
 	return zoneIds
-	// fmt.Println(zones.List[0].ID)
-}
-
-func rkszone(sz *ruckus.Client) {
-	zone, _ := sz.GetZone("615d18e9-0cc0-4e3d-b98e-f2a476b5a846")
-	fmt.Println(zone)
 }
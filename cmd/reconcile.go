@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/ApogeeNetworking/ruckus"
+	"gopkg.in/yaml.v3"
+)
+
+func planCmd(args []string) int {
+	return reconcileCmd("plan", args, true)
+}
+
+func applyCmd(args []string) int {
+	return reconcileCmd("apply", args, false)
+}
+
+// reconcileCmd computes (and unless dryRun applies) the Plan of a Desired State file
+func reconcileCmd(name string, args []string, dryRun bool) int {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	file := fs.String("f", "site.yaml", "desired state (YAML)")
+	prune := fs.Bool("prune", false, "delete zones, ap groups and wlans absent from the desired state")
+	logPath := fs.String("log", "", "write the machine-readable change log (JSON) to this file")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	desired, err := loadDesiredState(*file)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	sz, logout, err := connect()
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer logout()
+	ctx, cancel := interruptible()
+	defer cancel()
+
+	plan, err := sz.Reconcile(ctx, desired, ruckus.ReconcileOptions{DryRun: dryRun, Prune: *prune})
+	if plan != nil {
+		fmt.Print(plan.String())
+		if *logPath != "" {
+			if werr := writeChangeLog(*logPath, plan); werr != nil {
+				log.Println(werr)
+			}
+		}
+	}
+	if err != nil {
+		log.Printf("%s failed: %v", name, err)
+		return 1
+	}
+	return 0
+}
+
+func loadDesiredState(path string) (ruckus.DesiredState, error) {
+	var desired ruckus.DesiredState
+	d, err := ioutil.ReadFile(path)
+	if err != nil {
+		return desired, fmt.Errorf("failed to read desired state: %v", err)
+	}
+	if err := yaml.Unmarshal(d, &desired); err != nil {
+		return desired, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return desired, nil
+}
+
+func writeChangeLog(path string, plan *ruckus.Plan) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write change log: %v", err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(plan)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ApogeeNetworking/ruckus"
+)
+
+func rfCmd(args []string) int {
+	fs := flag.NewFlagSet("rf", flag.ContinueOnError)
+	zoneName := fs.String("zone", "", "name or ID of the zone to report")
+	format := fs.String("format", "table", "report format (table|csv)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *zoneName == "" {
+		log.Println("-zone is required")
+		return 2
+	}
+	if *format != "table" && *format != "csv" {
+		log.Printf("invalid format %s", *format)
+		return 2
+	}
+	sz, logout, err := connect()
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer logout()
+	zones, err := sz.GetAllZones(ruckus.RksOptions{})
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	var zoneID string
+	for _, z := range zones {
+		if z.ID == *zoneName || z.Name == *zoneName {
+			zoneID = z.ID
+			break
+		}
+	}
+	if zoneID == "" {
+		log.Printf("zone %s not found", *zoneName)
+		return 1
+	}
+	report, err := sz.RFReport(zoneID)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	if *format == "csv" {
+		err = writeRFCsv(os.Stdout, report)
+	} else {
+		err = writeRFTable(os.Stdout, report)
+	}
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	return 0
+}
+
+func writeRFTable(w io.Writer, r ruckus.ZoneRFReport) error {
+	dfs := "disabled"
+	if r.DfsChannelEnabled {
+		dfs = "enabled"
+	}
+	fmt.Fprintf(w, "Zone %s (DFS channels %s)\n\n", r.ZoneName, dfs)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BAND\tCHANNEL\tWIDTH\tAPS\tDFS")
+	for _, u := range r.Channels {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%s\n", u.Band, u.Channel, widthString(u.Width), u.Aps, yesNo(u.Dfs))
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nCo-channel APs\n")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "AP GROUP\tBAND\tCHANNEL\tAPS\tAP NAMES")
+	for _, cc := range r.CoChannel {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\n", cc.GroupName, cc.Band, cc.Channel, len(cc.ApNames), strings.Join(cc.ApNames, ", "))
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nFindings\n")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tBAND\tAP GROUP\tAP\tMAC\tDETAIL")
+	for _, f := range r.Findings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", f.Kind, f.Band, f.GroupName, f.ApName, f.ApMac, f.Detail)
+	}
+	return tw.Flush()
+}
+
+// writeRFCsv writes every section of the report as rows of a single CSV
+// the section column tells them apart
+func writeRFCsv(w io.Writer, r ruckus.ZoneRFReport) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"section", "zone", "band", "channel", "width", "aps", "dfs", "ap_group", "ap_name", "ap_mac", "detail"})
+	for _, u := range r.Channels {
+		cw.Write([]string{"channel", r.ZoneName, string(u.Band), strconv.Itoa(u.Channel), strconv.Itoa(u.Width), strconv.Itoa(u.Aps), strconv.FormatBool(u.Dfs), "", "", "", ""})
+	}
+	for _, cc := range r.CoChannel {
+		cw.Write([]string{"cochannel", r.ZoneName, string(cc.Band), strconv.Itoa(cc.Channel), "", strconv.Itoa(len(cc.ApNames)), "", cc.GroupName, strings.Join(cc.ApNames, ";"), "", ""})
+	}
+	for _, f := range r.Findings {
+		ch := ""
+		if f.Channel != 0 {
+			ch = strconv.Itoa(f.Channel)
+		}
+		cw.Write([]string{strings.ToLower(f.Kind), r.ZoneName, string(f.Band), ch, "", "", "", f.GroupName, f.ApName, f.ApMac, f.Detail})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func widthString(mhz int) string {
+	if mhz == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%dMHz", mhz)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ApogeeNetworking/ruckus"
+)
+
+// controller the subset of *ruckus.Client the Exporter depends on
+// a stub implementing it allows testing without a SmartZone Controller
+type controller interface {
+	Login() error
+	Logout() error
+	GetSysSum(o ruckus.RksOptions) (ruckus.RksSysSumRes, error)
+	GetAPs(o ruckus.RksOptions) ([]ruckus.RksAp, error)
+	GetWlans(o ruckus.RksOptions) ([]ruckus.RksWlan, error)
+	GetAlarms(o ruckus.RksOptions, state string) ([]ruckus.RksAlarm, error)
+}
+
+// collector scrapes the Controller at most once per ttl
+// concurrent Scrapes (ie several Prometheus Servers) share the cached result
+type collector struct {
+	ctrl controller
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu        sync.Mutex
+	cached    []byte
+	scrapedAt time.Time
+}
+
+func newCollector(ctrl controller, ttl time.Duration) *collector {
+	return &collector{ctrl: ctrl, ttl: ttl, now: time.Now}
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(c.scrape())
+}
+
+// scrape returns the cached Metrics refreshing them when older than ttl
+func (c *collector) scrape() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cached != nil && c.now().Sub(c.scrapedAt) < c.ttl {
+		return c.cached
+	}
+	m := c.collect()
+	var buf bytes.Buffer
+	m.write(&buf)
+	c.cached = buf.Bytes()
+	c.scrapedAt = c.now()
+	return c.cached
+}
+
+// collect retrieves everything from the Controller in a single session
+// a failing section is reported through ruckus_collector_success
+func (c *collector) collect() *metrics {
+	m := newMetrics()
+	start := c.now()
+	if err := c.ctrl.Login(); err != nil {
+		log.Printf("login failed: %v", err)
+		m.add("ruckus_up", "gauge", "Whether the Controller could be reached.", 0)
+		return m
+	}
+	defer func() {
+		if err := c.ctrl.Logout(); err != nil {
+			log.Printf("logout failed: %v", err)
+		}
+	}()
+	m.add("ruckus_up", "gauge", "Whether the Controller could be reached.", 1)
+
+	sections := []struct {
+		name string
+		fn   func(*metrics) error
+	}{
+		{"controller", c.collectController},
+		{"aps", c.collectAps},
+		{"wlans", c.collectWlans},
+		{"alarms", c.collectAlarms},
+	}
+	for _, s := range sections {
+		ok := 1.0
+		if err := s.fn(m); err != nil {
+			log.Printf("collecting %s failed: %v", s.name, err)
+			ok = 0
+		}
+		m.add("ruckus_collector_success", "gauge",
+			"Whether a section of the Controller was collected.", ok, "collector", s.name)
+	}
+	m.add("ruckus_scrape_duration_seconds", "gauge",
+		"Time spent collecting from the Controller.", c.now().Sub(start).Seconds())
+	return m
+}
+
+func (c *collector) collectController(m *metrics) error {
+	sum, err := c.ctrl.GetSysSum(ruckus.RksOptions{})
+	if err != nil {
+		return err
+	}
+	for _, ctrl := range sum.List {
+		m.add("ruckus_controller_uptime_seconds", "gauge",
+			"Uptime of the Controller node.", float64(ctrl.UptimeInSec),
+			"controller", ctrl.Name, "hostname", ctrl.Hostname)
+		m.add("ruckus_controller_info", "gauge",
+			"Version information of the Controller node.", 1,
+			"controller", ctrl.Name,
+			"hostname", ctrl.Hostname,
+			"model", ctrl.Model,
+			"serial", ctrl.SerialNumber,
+			"version", ctrl.Version,
+			"ap_version", ctrl.ApVersion,
+			"cluster_role", ctrl.ClusterRole,
+		)
+	}
+	return nil
+}
+
+func (c *collector) collectAps(m *metrics) error {
+	aps, err := c.ctrl.GetAPs(ruckus.RksOptions{})
+	if err != nil {
+		return err
+	}
+	type zoneStatus struct{ zone, status string }
+	type groupStatus struct{ zone, group, status string }
+	zones := make(map[zoneStatus]int)
+	groups := make(map[groupStatus]int)
+	for _, ap := range aps {
+		status := strings.ToLower(ap.Status)
+		zones[zoneStatus{ap.ZoneName, status}]++
+		groups[groupStatus{ap.ZoneName, ap.GroupName, status}]++
+		up := 0.0
+		if status == "online" {
+			up = 1
+		}
+		labels := []string{
+			"mac", ap.MacAddr,
+			"name", ap.ApName,
+			"zone", ap.ZoneName,
+			"group", ap.GroupName,
+		}
+		m.add("ruckus_ap_up", "gauge", "Whether the AP is online.", up, labels...)
+		m.add("ruckus_ap_clients", "gauge", "Clients associated with the AP.", float64(ap.Clients), labels...)
+	}
+	for k, n := range zones {
+		m.add("ruckus_zone_aps", "gauge", "APs in the Zone by status.", float64(n),
+			"zone", k.zone, "status", k.status)
+	}
+	for k, n := range groups {
+		m.add("ruckus_group_aps", "gauge", "APs in the AP Group by status.", float64(n),
+			"zone", k.zone, "group", k.group, "status", k.status)
+	}
+	return nil
+}
+
+func (c *collector) collectWlans(m *metrics) error {
+	wlans, err := c.ctrl.GetWlans(ruckus.RksOptions{})
+	if err != nil {
+		return err
+	}
+	for _, wlan := range wlans {
+		labels := []string{"zone", wlan.ZoneName, "wlan", wlan.Name, "ssid", wlan.SSID}
+		m.add("ruckus_wlan_clients", "gauge", "Clients associated with the WLAN.", float64(wlan.Client), labels...)
+		m.add("ruckus_wlan_traffic_bytes", "gauge", "Traffic of the WLAN as reported by the Controller.",
+			float64(wlan.Traffic), labels...)
+	}
+	return nil
+}
+
+func (c *collector) collectAlarms(m *metrics) error {
+	alarms, err := c.ctrl.GetAlarms(ruckus.RksOptions{}, "Outstanding")
+	if err != nil {
+		return err
+	}
+	counts := make(map[string]int)
+	for _, a := range alarms {
+		counts[strings.ToLower(a.Severity)]++
+	}
+	for severity, n := range counts {
+		m.add("ruckus_alarms", "gauge", "Outstanding Alarms by severity.", float64(n), "severity", severity)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ApogeeNetworking/ruckus"
+)
+
+// stubController a controller answering from memory
+type stubController struct {
+	loginErr error
+	logins   int
+	aps      []ruckus.RksAp
+}
+
+func (s *stubController) Login() error {
+	s.logins++
+	return s.loginErr
+}
+
+func (s *stubController) Logout() error { return nil }
+
+func (s *stubController) GetSysSum(o ruckus.RksOptions) (ruckus.RksSysSumRes, error) {
+	var sum ruckus.RksSysSumRes
+	sum.List = []ruckus.RksController{{Name: "sz1", Hostname: "sz1.example", UptimeInSec: 3600}}
+	return sum, nil
+}
+
+func (s *stubController) GetAPs(o ruckus.RksOptions) ([]ruckus.RksAp, error) {
+	return s.aps, nil
+}
+
+func (s *stubController) GetWlans(o ruckus.RksOptions) ([]ruckus.RksWlan, error) {
+	return nil, errors.New("wlans unavailable")
+}
+
+func (s *stubController) GetAlarms(o ruckus.RksOptions, state string) ([]ruckus.RksAlarm, error) {
+	return []ruckus.RksAlarm{{Severity: "Major"}, {Severity: "major"}}, nil
+}
+
+func TestCollectorExposition(t *testing.T) {
+	ctrl := &stubController{aps: []ruckus.RksAp{
+		{ApName: "ap1", MacAddr: "aa:bb", ZoneName: "z", GroupName: "g", Status: "Online", Clients: 4},
+		{ApName: "ap2", MacAddr: "cc:dd", ZoneName: "z", GroupName: "g", Status: "Offline"},
+	}}
+	c := newCollector(ctrl, time.Minute)
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	out := rec.Body.String()
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	want := []string{
+		"# TYPE ruckus_up gauge\nruckus_up 1\n",
+		`ruckus_ap_up{mac="aa:bb",name="ap1",zone="z",group="g"} 1`,
+		`ruckus_ap_up{mac="cc:dd",name="ap2",zone="z",group="g"} 0`,
+		`ruckus_ap_clients{mac="aa:bb",name="ap1",zone="z",group="g"} 4`,
+		`ruckus_zone_aps{zone="z",status="online"} 1`,
+		`ruckus_alarms{severity="major"} 2`,
+		`ruckus_controller_uptime_seconds{controller="sz1",hostname="sz1.example"} 3600`,
+		`ruckus_collector_success{collector="aps"} 1`,
+		`ruckus_collector_success{collector="wlans"} 0`,
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("exposition lacks %q\n%s", w, out)
+		}
+	}
+}
+
+func TestCollectorCacheTTL(t *testing.T) {
+	ctrl := &stubController{}
+	c := newCollector(ctrl, time.Minute)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	c.scrape()
+	now = now.Add(30 * time.Second)
+	c.scrape()
+	if ctrl.logins != 1 {
+		t.Fatalf("scrape within the ttl hit the controller: %d logins", ctrl.logins)
+	}
+	now = now.Add(31 * time.Second)
+	c.scrape()
+	if ctrl.logins != 2 {
+		t.Fatalf("scrape past the ttl reused the cache: %d logins", ctrl.logins)
+	}
+}
+
+func TestCollectorLoginFailure(t *testing.T) {
+	ctrl := &stubController{loginErr: &ruckus.RksError{StatusCode: 401}}
+	out := string(newCollector(ctrl, time.Minute).scrape())
+	if !strings.Contains(out, "ruckus_up 0\n") {
+		t.Errorf("ruckus_up not 0 on a failed login:\n%s", out)
+	}
+	if strings.Contains(out, "ruckus_collector_success") {
+		t.Errorf("sections collected after a failed login:\n%s", out)
+	}
+}
@@ -0,0 +1,36 @@
+// Command ruckus-exporter exposes SmartZone Controller and AP Metrics
+// to Prometheus on /metrics
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ApogeeNetworking/ruckus"
+	"github.com/subosito/gotenv"
+)
+
+var apiVer, host, user, pass string
+
+func init() {
+	gotenv.Load()
+	apiVer = os.Getenv("RKS_API_VER")
+	host = os.Getenv("RKS_HOST")
+	user = os.Getenv("RKS_USER")
+	pass = os.Getenv("RKS_PASS")
+}
+
+func main() {
+	listen := flag.String("listen", ":9797", "address to serve /metrics on")
+	ttl := flag.Duration("cache-ttl", 60*time.Second, "how long a scrape of the controller is reused")
+	verifyTLS := flag.Bool("verify-tls", false, "verify the controller certificate")
+	flag.Parse()
+
+	sz := ruckus.New(apiVer, host, user, pass, !*verifyTLS)
+	http.Handle("/metrics", newCollector(sz, *ttl))
+	log.Printf("serving metrics of %s on %s", host, *listen)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ApogeeNetworking/ruckus"
+)
+
+func snapshotCmd(args []string) int {
+	if len(args) > 0 && args[0] == "diff" {
+		return snapshotDiffCmd(args[1:])
+	}
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	out := fs.String("o", "", "write the snapshot as a tar.gz to this file")
+	dir := fs.String("dir", "", "write the snapshot as a directory tree rooted here")
+	keepSecrets := fs.Bool("keep-secrets", false, "do not redact passwords, passphrases and secrets")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if (*out == "") == (*dir == "") {
+		log.Println("one of -o or -dir is required")
+		return 2
+	}
+	sz, logout, err := connect()
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer logout()
+	ctx, cancel := interruptible()
+	defer cancel()
+
+	o := ruckus.SnapshotOptions{KeepSecrets: *keepSecrets}
+	if *dir != "" {
+		err = sz.SnapshotDir(ctx, *dir, o)
+	} else {
+		err = writeSnapshot(ctx, sz, *out, o)
+	}
+	if err != nil {
+		log.Printf("snapshot failed: %v", err)
+		return 1
+	}
+	return 0
+}
+
+func writeSnapshot(ctx context.Context, sz *ruckus.Client, path string, o ruckus.SnapshotOptions) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := sz.Snapshot(ctx, f, o); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+func snapshotDiffCmd(args []string) int {
+	fs := flag.NewFlagSet("snapshot diff", flag.ContinueOnError)
+	format := fs.String("format", "text", "output format (text|json)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 2 {
+		log.Println("usage: snapshot diff old.tgz new.tgz")
+		return 2
+	}
+	old, err := os.Open(fs.Arg(0))
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer old.Close()
+	new, err := os.Open(fs.Arg(1))
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer new.Close()
+	changes, err := ruckus.DiffSnapshots(old, new)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(changes)
+		return 0
+	}
+	if len(changes) == 0 {
+		fmt.Println("No changes.")
+		return 0
+	}
+	symbols := map[string]string{"added": "+", "removed": "-", "modified": "~"}
+	for _, ch := range changes {
+		fmt.Printf("%s %s\n", symbols[ch.Status], ch.File)
+		for _, d := range ch.Diffs {
+			fmt.Printf("    %s: %s => %s\n", d.Path, jsonString(d.Old), jsonString(d.New))
+		}
+	}
+	return 0
+}
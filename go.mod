@@ -5,4 +5,5 @@ go 1.15
 require (
 	github.com/stretchr/testify v1.6.1 // indirect
 	github.com/subosito/gotenv v1.2.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,297 @@
+package ruckus
+
+import "fmt"
+
+// RksRadiusServer a RADIUS Server of an AAA Profile|Service
+type RksRadiusServer struct {
+	IP           string `json:"ip"`
+	Port         int    `json:"port,omitempty"`
+	SharedSecret string `json:"sharedSecret,omitempty"`
+}
+
+// RksRadiusProfile properties of a RADIUS Authentication|Accounting Profile
+// (of a Zone) or Service (of the System)
+type RksRadiusProfile struct {
+	ID          string           `json:"id,omitempty"`
+	ZoneID      string           `json:"zoneId,omitempty"`
+	DomainID    string           `json:"domainId,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Primary     *RksRadiusServer `json:"primary,omitempty"`
+	Secondary   *RksRadiusServer `json:"secondary,omitempty"`
+}
+
+// Endpoints of the AAA Profiles of a Zone (formatted with the Zone ID)
+// and of the AAA Services of the System
+const (
+	zoneAuthEp = "/rkszones/%s/aaa/radius"
+	zoneAcctEp = "/rkszones/%s/aaa/accounting"
+	authSvcEp  = "/services/auth/radius"
+	acctSvcEp  = "/services/acct/radius"
+)
+
+// GetZoneAuthProfiles retrieves the RADIUS Authentication Profiles of a Zone
+func (c *Client) GetZoneAuthProfiles(zoneID string) ([]RksRadiusProfile, error) {
+	return c.getRadiusProfiles(fmt.Sprintf(zoneAuthEp, zoneID), RksOptions{})
+}
+
+// GetZoneAuthProfile retrieves a RADIUS Authentication Profile of a Zone
+func (c *Client) GetZoneAuthProfile(zoneID, id string) (RksRadiusProfile, error) {
+	return c.getRadiusProfile(fmt.Sprintf(zoneAuthEp, zoneID), id)
+}
+
+// CreateZoneAuthProfile creates a RADIUS Authentication Profile in a Zone returning its ID
+func (c *Client) CreateZoneAuthProfile(zoneID string, p RksRadiusProfile) (string, error) {
+	return c.createRadiusProfile(fmt.Sprintf(zoneAuthEp, zoneID), p)
+}
+
+// UpdateZoneAuthProfile modifies the fields of a RADIUS Authentication Profile set in p
+func (c *Client) UpdateZoneAuthProfile(zoneID, id string, p RksRadiusProfile) error {
+	return c.updateRadiusProfile(fmt.Sprintf(zoneAuthEp, zoneID), id, p)
+}
+
+// PatchZoneAuthProfile PATCHes the fields of a RADIUS Authentication Profile
+// that differ from original, the Profile as retrieved (see Patch)
+func (c *Client) PatchZoneAuthProfile(zoneID string, original, modified RksRadiusProfile) error {
+	return c.patchRadiusProfile(fmt.Sprintf(zoneAuthEp, zoneID), original, modified)
+}
+
+// DeleteZoneAuthProfile removes a RADIUS Authentication Profile from a Zone
+func (c *Client) DeleteZoneAuthProfile(zoneID, id string) error {
+	return c.deleteRadiusProfile(fmt.Sprintf(zoneAuthEp, zoneID), id)
+}
+
+// GetZoneAcctProfiles retrieves the RADIUS Accounting Profiles of a Zone
+func (c *Client) GetZoneAcctProfiles(zoneID string) ([]RksRadiusProfile, error) {
+	return c.getRadiusProfiles(fmt.Sprintf(zoneAcctEp, zoneID), RksOptions{})
+}
+
+// GetZoneAcctProfile retrieves a RADIUS Accounting Profile of a Zone
+func (c *Client) GetZoneAcctProfile(zoneID, id string) (RksRadiusProfile, error) {
+	return c.getRadiusProfile(fmt.Sprintf(zoneAcctEp, zoneID), id)
+}
+
+// CreateZoneAcctProfile creates a RADIUS Accounting Profile in a Zone returning its ID
+func (c *Client) CreateZoneAcctProfile(zoneID string, p RksRadiusProfile) (string, error) {
+	return c.createRadiusProfile(fmt.Sprintf(zoneAcctEp, zoneID), p)
+}
+
+// UpdateZoneAcctProfile modifies the fields of a RADIUS Accounting Profile set in p
+func (c *Client) UpdateZoneAcctProfile(zoneID, id string, p RksRadiusProfile) error {
+	return c.updateRadiusProfile(fmt.Sprintf(zoneAcctEp, zoneID), id, p)
+}
+
+// PatchZoneAcctProfile PATCHes the fields of a RADIUS Accounting Profile
+// that differ from original, the Profile as retrieved (see Patch)
+func (c *Client) PatchZoneAcctProfile(zoneID string, original, modified RksRadiusProfile) error {
+	return c.patchRadiusProfile(fmt.Sprintf(zoneAcctEp, zoneID), original, modified)
+}
+
+// DeleteZoneAcctProfile removes a RADIUS Accounting Profile from a Zone
+func (c *Client) DeleteZoneAcctProfile(zoneID, id string) error {
+	return c.deleteRadiusProfile(fmt.Sprintf(zoneAcctEp, zoneID), id)
+}
+
+// GetAuthServices retrieves the RADIUS Authentication Services of the System
+func (c *Client) GetAuthServices(o RksOptions) ([]RksRadiusProfile, error) {
+	return c.getRadiusProfiles(authSvcEp, o)
+}
+
+// GetAuthService retrieves a RADIUS Authentication Service
+func (c *Client) GetAuthService(id string) (RksRadiusProfile, error) {
+	return c.getRadiusProfile(authSvcEp, id)
+}
+
+// CreateAuthService creates a RADIUS Authentication Service returning its ID
+func (c *Client) CreateAuthService(p RksRadiusProfile) (string, error) {
+	return c.createRadiusProfile(authSvcEp, p)
+}
+
+// UpdateAuthService modifies the fields of a RADIUS Authentication Service set in p
+func (c *Client) UpdateAuthService(id string, p RksRadiusProfile) error {
+	return c.updateRadiusProfile(authSvcEp, id, p)
+}
+
+// PatchAuthService PATCHes the fields of a RADIUS Authentication Service
+// that differ from original, the Service as retrieved (see Patch)
+func (c *Client) PatchAuthService(original, modified RksRadiusProfile) error {
+	return c.patchRadiusProfile(authSvcEp, original, modified)
+}
+
+// DeleteAuthService removes a RADIUS Authentication Service
+func (c *Client) DeleteAuthService(id string) error {
+	return c.deleteRadiusProfile(authSvcEp, id)
+}
+
+// GetAcctServices retrieves the RADIUS Accounting Services of the System
+func (c *Client) GetAcctServices(o RksOptions) ([]RksRadiusProfile, error) {
+	return c.getRadiusProfiles(acctSvcEp, o)
+}
+
+// GetAcctService retrieves a RADIUS Accounting Service
+func (c *Client) GetAcctService(id string) (RksRadiusProfile, error) {
+	return c.getRadiusProfile(acctSvcEp, id)
+}
+
+// CreateAcctService creates a RADIUS Accounting Service returning its ID
+func (c *Client) CreateAcctService(p RksRadiusProfile) (string, error) {
+	return c.createRadiusProfile(acctSvcEp, p)
+}
+
+// UpdateAcctService modifies the fields of a RADIUS Accounting Service set in p
+func (c *Client) UpdateAcctService(id string, p RksRadiusProfile) error {
+	return c.updateRadiusProfile(acctSvcEp, id, p)
+}
+
+// PatchAcctService PATCHes the fields of a RADIUS Accounting Service
+// that differ from original, the Service as retrieved (see Patch)
+func (c *Client) PatchAcctService(original, modified RksRadiusProfile) error {
+	return c.patchRadiusProfile(acctSvcEp, original, modified)
+}
+
+// DeleteAcctService removes a RADIUS Accounting Service
+func (c *Client) DeleteAcctService(id string) error {
+	return c.deleteRadiusProfile(acctSvcEp, id)
+}
+
+func (c *Client) getRadiusProfiles(ep string, o RksOptions) ([]RksRadiusProfile, error) {
+	var profiles []RksRadiusProfile
+	err := c.getList(ep, o, &profiles)
+	return profiles, err
+}
+
+func (c *Client) getRadiusProfile(ep, id string) (RksRadiusProfile, error) {
+	var p RksRadiusProfile
+	err := c.send("GET", ep+"/"+id, RksOptions{}, nil, &p)
+	return p, err
+}
+
+func (c *Client) createRadiusProfile(ep string, p RksRadiusProfile) (string, error) {
+	var created RksObject
+	err := c.send("POST", ep, RksOptions{}, p, &created)
+	return created.ID, err
+}
+
+func (c *Client) updateRadiusProfile(ep, id string, p RksRadiusProfile) error {
+	return c.update(ep+"/"+id, p)
+}
+
+func (c *Client) patchRadiusProfile(ep string, original, modified RksRadiusProfile) error {
+	return c.patch(ep+"/"+original.ID, original, modified)
+}
+
+func (c *Client) deleteRadiusProfile(ep, id string) error {
+	return c.send("DELETE", ep+"/"+id, RksOptions{}, nil, nil)
+}
+
+// RadiusRotation the outcome of rotating the Shared Secret of one Profile|Service
+type RadiusRotation struct {
+	DomainID string
+	// empty for the Services of the System
+	ZoneID   string
+	ZoneName string
+	// auth|acct
+	Kind        string
+	ProfileID   string
+	ProfileName string
+	Err         error
+}
+
+// RotateRadiusSecret sets newSecret on every RADIUS Authentication|Accounting
+// Profile and Service referencing serverIP across all Zones of the Domain of
+// the Client (the System unless InDomain) and of every Domain below it
+// the returned error is only set when the Profiles could not be retrieved;
+// failed updates are reported in the RadiusRotation of the Profile
+func (c *Client) RotateRadiusSecret(serverIP, newSecret string) ([]RadiusRotation, error) {
+	domains, err := c.GetDomains(RksOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains: %v", err)
+	}
+	var results []RadiusRotation
+	seen := make(map[string]bool)
+	rotate := func(dc *Client, ep string, r RadiusRotation, p RksRadiusProfile) error {
+		if seen[ep+p.ID] {
+			return nil
+		}
+		seen[ep+p.ID] = true
+		full, err := dc.getRadiusProfile(ep, p.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get %s profile %s: %v", r.Kind, p.Name, err)
+		}
+		upd, ok := withSecret(full, serverIP, newSecret)
+		if !ok {
+			return nil
+		}
+		r.ProfileID, r.ProfileName = full.ID, full.Name
+		r.Err = dc.updateRadiusProfile(ep, full.ID, upd)
+		results = append(results, r)
+		return nil
+	}
+
+	// the Domain of the Client itself comes first; GetDomains only lists those below it
+	type scope struct {
+		dc   *Client
+		id   string
+		name string
+	}
+	scopes := []scope{{c, c.domainID, "System"}}
+	if c.domainID != "" {
+		scopes[0].name = c.domainID
+	}
+	for _, d := range FlattenDomains(domains) {
+		if d.ID != c.domainID {
+			scopes = append(scopes, scope{c.InDomain(d.ID), d.ID, d.Name})
+		}
+	}
+	for _, d := range scopes {
+		dc := d.dc
+		for _, svc := range []struct{ kind, ep string }{{"auth", authSvcEp}, {"acct", acctSvcEp}} {
+			profiles, err := dc.getRadiusProfiles(svc.ep, RksOptions{})
+			if err != nil {
+				return results, fmt.Errorf("failed to get %s services of %s: %v", svc.kind, d.name, err)
+			}
+			for _, p := range profiles {
+				if err := rotate(dc, svc.ep, RadiusRotation{DomainID: d.id, Kind: svc.kind}, p); err != nil {
+					return results, err
+				}
+			}
+		}
+		zones, err := dc.GetAllZones(RksOptions{})
+		if err != nil {
+			return results, fmt.Errorf("failed to get zones of %s: %v", d.name, err)
+		}
+		for _, z := range zones {
+			for _, prf := range []struct{ kind, ep string }{{"auth", zoneAuthEp}, {"acct", zoneAcctEp}} {
+				ep := fmt.Sprintf(prf.ep, z.ID)
+				profiles, err := dc.getRadiusProfiles(ep, RksOptions{})
+				if err != nil {
+					return results, fmt.Errorf("failed to get %s profiles of %s: %v", prf.kind, z.Name, err)
+				}
+				r := RadiusRotation{DomainID: d.id, ZoneID: z.ID, ZoneName: z.Name, Kind: prf.kind}
+				for _, p := range profiles {
+					if err := rotate(dc, ep, r, p); err != nil {
+						return results, err
+					}
+				}
+			}
+		}
+	}
+	return results, nil
+}
+
+// withSecret returns the update setting secret on the Servers of p at serverIP
+// and whether p references serverIP at all
+func withSecret(p RksRadiusProfile, serverIP, secret string) (RksRadiusProfile, bool) {
+	var upd RksRadiusProfile
+	if p.Primary != nil && p.Primary.IP == serverIP {
+		srv := *p.Primary
+		srv.SharedSecret = secret
+		upd.Primary = &srv
+	}
+	if p.Secondary != nil && p.Secondary.IP == serverIP {
+		srv := *p.Secondary
+		srv.SharedSecret = secret
+		upd.Secondary = &srv
+	}
+	return upd, upd.Primary != nil || upd.Secondary != nil
+}
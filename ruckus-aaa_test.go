@@ -0,0 +1,30 @@
+package ruckus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRotateRadiusSecretIncludesTheSystemDomain(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("GET /domains", `{"list":[{"id":"d1","name":"tenant"}]}`)
+	f.on("GET /domains/d1/subdomain", `{"list":[]}`)
+	f.on("GET /rkszones", `{"list":[]}`)
+	f.on("GET /services/auth/radius", `{"list":[{"id":"p1","name":"radius"}]}`)
+	f.on("GET /services/auth/radius?domainId=d1", `{"list":[]}`)
+	f.on("GET /services/acct/radius", `{"list":[]}`)
+	f.on("GET /services/auth/radius/p1", `{"id":"p1","name":"radius","primary":{"ip":"10.0.0.1","port":1812,"sharedSecret":"old"}}`)
+	f.on("PATCH /services/auth/radius/p1", ``)
+
+	results, err := c.RotateRadiusSecret("10.0.0.1", "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].DomainID != "" || results[0].Err != nil {
+		t.Fatalf("results = %+v; want the System auth service rotated", results)
+	}
+	patched := f.received("PATCH /services/auth/radius/p1")
+	if len(patched) != 1 || !strings.Contains(patched[0].Body, `"sharedSecret":"new"`) {
+		t.Errorf("System service patched with %+v", patched)
+	}
+}
@@ -0,0 +1,476 @@
+package ruckus
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Actions and Directions of Access Control Rules
+const (
+	ActionAllow = "ALLOW"
+	ActionBlock = "BLOCK"
+
+	DirectionInbound  = "INBOUND"
+	DirectionOutbound = "OUTBOUND"
+	DirectionDual     = "DUAL"
+)
+
+// RksL2Acl properties of an L2 (MAC) Access Control Policy of a Zone
+type RksL2Acl struct {
+	ID          string `json:"id,omitempty"`
+	ZoneID      string `json:"zoneId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// ALLOW only the MACs of RuleMacs or BLOCK them
+	Restriction string   `json:"restriction,omitempty"`
+	RuleMacs    []string `json:"ruleMacs,omitempty"`
+}
+
+// Validate checks the fields of the Policy that are set
+func (a RksL2Acl) Validate() error {
+	if err := validAction("restriction", a.Restriction); err != nil {
+		return err
+	}
+	for _, mac := range a.RuleMacs {
+		if _, err := net.ParseMAC(mac); err != nil {
+			return fmt.Errorf("invalid rule mac %q", mac)
+		}
+	}
+	return nil
+}
+
+// RksL3Acp properties of an L3 Access Control Policy of a Zone
+type RksL3Acp struct {
+	ID            string      `json:"id,omitempty"`
+	ZoneID        string      `json:"zoneId,omitempty"`
+	Name          string      `json:"name,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	DefaultAction string      `json:"defaultAction,omitempty"`
+	Rules         []RksL3Rule `json:"l3AclRuleList,omitempty"`
+}
+
+// RksL3Rule a Rule of an L3 Access Control Policy
+type RksL3Rule struct {
+	Description string `json:"description,omitempty"`
+	Priority    int    `json:"priority,omitempty"`
+	Action      string `json:"action"`
+	Direction   string `json:"direction"`
+	// TCP|UDP|ICMP_ICMPV4|... empty for any Protocol
+	Protocol    string         `json:"protocol,omitempty"`
+	Source      *RksL3Endpoint `json:"source,omitempty"`
+	Destination *RksL3Endpoint `json:"destination,omitempty"`
+}
+
+// RksL3Endpoint the Source|Destination of an L3 Rule; the zero value matches anything
+type RksL3Endpoint struct {
+	IP              string `json:"ip,omitempty"`
+	IPMask          string `json:"ipMask,omitempty"`
+	EnableIPSubnet  bool   `json:"enableIpSubnet"`
+	Port            int    `json:"port,omitempty"`
+	EnablePortRange bool   `json:"enablePortRange"`
+	PortRangeStart  int    `json:"portRangeStart,omitempty"`
+	PortRangeEnd    int    `json:"portRangeEnd,omitempty"`
+}
+
+// NewL3Endpoint creates an L3 Endpoint from an IP or CIDR (ie 10.0.0.0/8)
+// and a Port or Port Range (ie 443, 1000-2000); either may be empty
+func NewL3Endpoint(cidr, ports string) (*RksL3Endpoint, error) {
+	ep := &RksL3Endpoint{}
+	if cidr != "" {
+		if strings.Contains(cidr, "/") {
+			ip, n, err := net.ParseCIDR(cidr)
+			if err != nil || ip.To4() == nil {
+				return nil, fmt.Errorf("invalid cidr %q", cidr)
+			}
+			ep.IP = n.IP.String()
+			ep.IPMask = net.IP(n.Mask).String()
+			ep.EnableIPSubnet = true
+		} else {
+			ip := net.ParseIP(cidr)
+			if ip == nil || ip.To4() == nil {
+				return nil, fmt.Errorf("invalid ip %q", cidr)
+			}
+			ep.IP = ip.String()
+		}
+	}
+	if ports != "" {
+		bounds := strings.SplitN(ports, "-", 2)
+		start, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", ports)
+		}
+		if len(bounds) == 1 {
+			ep.Port = start
+		} else {
+			end, err := strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid port range %q", ports)
+			}
+			ep.EnablePortRange = true
+			ep.PortRangeStart, ep.PortRangeEnd = start, end
+		}
+	}
+	return ep, ep.validate()
+}
+
+func (e RksL3Endpoint) validate() error {
+	if e.IP != "" && net.ParseIP(e.IP) == nil {
+		return fmt.Errorf("invalid ip %q", e.IP)
+	}
+	if e.EnableIPSubnet {
+		m := net.ParseIP(e.IPMask).To4()
+		if m == nil {
+			return fmt.Errorf("invalid ip mask %q", e.IPMask)
+		}
+		if _, bits := net.IPMask(m).Size(); bits == 0 {
+			return fmt.Errorf("ip mask %s is not contiguous", e.IPMask)
+		}
+	}
+	if e.EnablePortRange {
+		if !validPort(e.PortRangeStart) || !validPort(e.PortRangeEnd) || e.PortRangeStart > e.PortRangeEnd {
+			return fmt.Errorf("invalid port range %d-%d", e.PortRangeStart, e.PortRangeEnd)
+		}
+	} else if e.Port != 0 && !validPort(e.Port) {
+		return fmt.Errorf("invalid port %d", e.Port)
+	}
+	return nil
+}
+
+func validPort(p int) bool {
+	return p > 0 && p < 65536
+}
+
+// Validate checks the fields of the Rule
+func (r RksL3Rule) Validate() error {
+	if r.Action == "" {
+		return fmt.Errorf("action is required")
+	}
+	if err := validAction("action", r.Action); err != nil {
+		return err
+	}
+	switch r.Direction {
+	case DirectionInbound, DirectionOutbound, DirectionDual:
+	default:
+		return fmt.Errorf("invalid direction %q", r.Direction)
+	}
+	hasPorts := false
+	for _, ep := range []*RksL3Endpoint{r.Source, r.Destination} {
+		if ep == nil {
+			continue
+		}
+		if err := ep.validate(); err != nil {
+			return err
+		}
+		hasPorts = hasPorts || ep.Port != 0 || ep.EnablePortRange
+	}
+	if hasPorts && r.Protocol != "TCP" && r.Protocol != "UDP" {
+		return fmt.Errorf("ports require the TCP or UDP protocol, not %q", r.Protocol)
+	}
+	return nil
+}
+
+// Validate checks the fields of the Policy that are set and all its Rules
+func (a RksL3Acp) Validate() error {
+	if err := validAction("default action", a.DefaultAction); err != nil {
+		return err
+	}
+	for i, r := range a.Rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("rule %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// RksDevicePolicy properties of a Device (OS) Policy of a Zone
+type RksDevicePolicy struct {
+	ID            string          `json:"id,omitempty"`
+	ZoneID        string          `json:"zoneId,omitempty"`
+	Name          string          `json:"name,omitempty"`
+	Description   string          `json:"description,omitempty"`
+	DefaultAction string          `json:"defaultAction,omitempty"`
+	Rules         []RksDeviceRule `json:"rule,omitempty"`
+}
+
+// RksDeviceRule a Rule of a Device Policy
+type RksDeviceRule struct {
+	Description string `json:"description,omitempty"`
+	// Windows|Android|Apple_iOS|Mac_OS|Linux|Gaming|...
+	DeviceType string `json:"deviceType"`
+	Action     string `json:"action"`
+	// 0 keeps the VLAN of the WLAN
+	Vlan int `json:"vlan,omitempty"`
+	// Mbps; 0 unlimited
+	UplinkRateLimiting   float64 `json:"uplinkRateLimiting,omitempty"`
+	DownlinkRateLimiting float64 `json:"downlinkRateLimiting,omitempty"`
+}
+
+// Validate checks the fields of the Policy that are set and all its Rules
+func (p RksDevicePolicy) Validate() error {
+	if err := validAction("default action", p.DefaultAction); err != nil {
+		return err
+	}
+	for i, r := range p.Rules {
+		if r.DeviceType == "" {
+			return fmt.Errorf("rule %d: device type is required", i+1)
+		}
+		if r.Action == "" {
+			return fmt.Errorf("rule %d: action is required", i+1)
+		}
+		if err := validAction("action", r.Action); err != nil {
+			return fmt.Errorf("rule %d: %v", i+1, err)
+		}
+		if r.Vlan < 0 || r.Vlan > 4094 {
+			return fmt.Errorf("rule %d: invalid vlan %d", i+1, r.Vlan)
+		}
+		if r.UplinkRateLimiting < 0 || r.DownlinkRateLimiting < 0 {
+			return fmt.Errorf("rule %d: rate limits cannot be negative", i+1)
+		}
+	}
+	return nil
+}
+
+// RksFirewallProfile properties of a Firewall Profile bundling the Policies
+// (and Rate Limits) a WLAN enforces
+type RksFirewallProfile struct {
+	ID          string `json:"id,omitempty"`
+	DomainID    string `json:"domainId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Mbps; 0 unlimited
+	UplinkRateLimiting   float64 `json:"uplinkRateLimitingMbps,omitempty"`
+	DownlinkRateLimiting float64 `json:"downlinkRateLimitingMbps,omitempty"`
+	L2AclID              string  `json:"l2AccessControlPolicyId,omitempty"`
+	L3AcpID              string  `json:"l3AccessControlPolicyId,omitempty"`
+	DevicePolicyID       string  `json:"devicePolicyId,omitempty"`
+}
+
+// Validate checks the fields of the Profile that are set
+func (p RksFirewallProfile) Validate() error {
+	if p.UplinkRateLimiting < 0 || p.DownlinkRateLimiting < 0 {
+		return fmt.Errorf("rate limits cannot be negative")
+	}
+	return nil
+}
+
+func validAction(field, a string) error {
+	switch a {
+	case "", ActionAllow, ActionBlock:
+		return nil
+	}
+	return fmt.Errorf("invalid %s %q", field, a)
+}
+
+// validator a Policy that checks itself before it is submitted
+type validator interface {
+	Validate() error
+}
+
+// submitPolicy validates p then sends it; a Policy being created must be named
+func (c *Client) submitPolicy(method, ep, name string, p validator, out interface{}) error {
+	if method == "POST" && name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if method == "PATCH" {
+		return c.update(ep, p)
+	}
+	return c.send(method, ep, RksOptions{}, p, out)
+}
+
+// patchPolicy validates modified then PATCHes its fields that differ from original
+func (c *Client) patchPolicy(ep string, original, modified validator) error {
+	if err := modified.Validate(); err != nil {
+		return err
+	}
+	return c.patch(ep, original, modified)
+}
+
+// GetL2Acls retrieves the L2 Access Control Policies of a Zone
+func (c *Client) GetL2Acls(zoneID string) ([]RksL2Acl, error) {
+	var acls []RksL2Acl
+	err := c.getList(fmt.Sprintf("/rkszones/%s/l2ACL", zoneID), RksOptions{}, &acls)
+	return acls, err
+}
+
+// GetL2Acl retrieves an L2 Access Control Policy of a Zone
+func (c *Client) GetL2Acl(zoneID, id string) (RksL2Acl, error) {
+	var acl RksL2Acl
+	err := c.send("GET", fmt.Sprintf("/rkszones/%s/l2ACL/%s", zoneID, id), RksOptions{}, nil, &acl)
+	return acl, err
+}
+
+// CreateL2Acl validates and creates an L2 Access Control Policy in a Zone returning its ID
+func (c *Client) CreateL2Acl(zoneID string, a RksL2Acl) (string, error) {
+	var created RksObject
+	err := c.submitPolicy("POST", fmt.Sprintf("/rkszones/%s/l2ACL", zoneID), a.Name, a, &created)
+	return created.ID, err
+}
+
+// UpdateL2Acl validates and modifies the fields of an L2 Access Control Policy set in a
+func (c *Client) UpdateL2Acl(zoneID, id string, a RksL2Acl) error {
+	return c.submitPolicy("PATCH", fmt.Sprintf("/rkszones/%s/l2ACL/%s", zoneID, id), a.Name, a, nil)
+}
+
+// PatchL2Acl validates modified and PATCHes the fields of an L2 Access Control Policy
+// that differ from original, the Policy as retrieved (see Patch); unlike
+// UpdateL2Acl it clears the fields modified leaves empty (ie RuleMacs)
+func (c *Client) PatchL2Acl(zoneID string, original, modified RksL2Acl) error {
+	return c.patchPolicy(fmt.Sprintf("/rkszones/%s/l2ACL/%s", zoneID, original.ID), original, modified)
+}
+
+// DeleteL2Acl removes an L2 Access Control Policy from a Zone
+func (c *Client) DeleteL2Acl(zoneID, id string) error {
+	return c.send("DELETE", fmt.Sprintf("/rkszones/%s/l2ACL/%s", zoneID, id), RksOptions{}, nil, nil)
+}
+
+// GetL3Acps retrieves the L3 Access Control Policies of a Zone
+func (c *Client) GetL3Acps(zoneID string) ([]RksL3Acp, error) {
+	var acps []RksL3Acp
+	err := c.getList(fmt.Sprintf("/rkszones/%s/l3ACP", zoneID), RksOptions{}, &acps)
+	return acps, err
+}
+
+// GetL3Acp retrieves an L3 Access Control Policy of a Zone
+func (c *Client) GetL3Acp(zoneID, id string) (RksL3Acp, error) {
+	var acp RksL3Acp
+	err := c.send("GET", fmt.Sprintf("/rkszones/%s/l3ACP/%s", zoneID, id), RksOptions{}, nil, &acp)
+	return acp, err
+}
+
+// CreateL3Acp validates and creates an L3 Access Control Policy in a Zone returning its ID
+func (c *Client) CreateL3Acp(zoneID string, a RksL3Acp) (string, error) {
+	var created RksObject
+	err := c.submitPolicy("POST", fmt.Sprintf("/rkszones/%s/l3ACP", zoneID), a.Name, a, &created)
+	return created.ID, err
+}
+
+// UpdateL3Acp validates and modifies the fields of an L3 Access Control Policy set in a
+// Rules replace those of the Policy
+func (c *Client) UpdateL3Acp(zoneID, id string, a RksL3Acp) error {
+	return c.submitPolicy("PATCH", fmt.Sprintf("/rkszones/%s/l3ACP/%s", zoneID, id), a.Name, a, nil)
+}
+
+// PatchL3Acp validates modified and PATCHes the fields of an L3 Access Control Policy
+// that differ from original, the Policy as retrieved (see Patch); unlike
+// UpdateL3Acp it clears the fields modified leaves empty (ie every Rule)
+func (c *Client) PatchL3Acp(zoneID string, original, modified RksL3Acp) error {
+	return c.patchPolicy(fmt.Sprintf("/rkszones/%s/l3ACP/%s", zoneID, original.ID), original, modified)
+}
+
+// DeleteL3Acp removes an L3 Access Control Policy from a Zone
+func (c *Client) DeleteL3Acp(zoneID, id string) error {
+	return c.send("DELETE", fmt.Sprintf("/rkszones/%s/l3ACP/%s", zoneID, id), RksOptions{}, nil, nil)
+}
+
+// GetDevicePolicies retrieves the Device Policies of a Zone
+func (c *Client) GetDevicePolicies(zoneID string) ([]RksDevicePolicy, error) {
+	var dps []RksDevicePolicy
+	err := c.getList(fmt.Sprintf("/rkszones/%s/devicePolicy", zoneID), RksOptions{}, &dps)
+	return dps, err
+}
+
+// GetDevicePolicy retrieves a Device Policy of a Zone
+func (c *Client) GetDevicePolicy(zoneID, id string) (RksDevicePolicy, error) {
+	var dp RksDevicePolicy
+	err := c.send("GET", fmt.Sprintf("/rkszones/%s/devicePolicy/%s", zoneID, id), RksOptions{}, nil, &dp)
+	return dp, err
+}
+
+// CreateDevicePolicy validates and creates a Device Policy in a Zone returning its ID
+func (c *Client) CreateDevicePolicy(zoneID string, p RksDevicePolicy) (string, error) {
+	var created RksObject
+	err := c.submitPolicy("POST", fmt.Sprintf("/rkszones/%s/devicePolicy", zoneID), p.Name, p, &created)
+	return created.ID, err
+}
+
+// UpdateDevicePolicy validates and modifies the fields of a Device Policy set in p
+func (c *Client) UpdateDevicePolicy(zoneID, id string, p RksDevicePolicy) error {
+	return c.submitPolicy("PATCH", fmt.Sprintf("/rkszones/%s/devicePolicy/%s", zoneID, id), p.Name, p, nil)
+}
+
+// PatchDevicePolicy validates modified and PATCHes the fields of a Device Policy
+// that differ from original, the Policy as retrieved (see Patch); unlike
+// UpdateDevicePolicy it clears the fields modified leaves empty (ie every Rule)
+func (c *Client) PatchDevicePolicy(zoneID string, original, modified RksDevicePolicy) error {
+	return c.patchPolicy(fmt.Sprintf("/rkszones/%s/devicePolicy/%s", zoneID, original.ID), original, modified)
+}
+
+// DeleteDevicePolicy removes a Device Policy from a Zone
+func (c *Client) DeleteDevicePolicy(zoneID, id string) error {
+	return c.send("DELETE", fmt.Sprintf("/rkszones/%s/devicePolicy/%s", zoneID, id), RksOptions{}, nil, nil)
+}
+
+// GetFirewallProfiles retrieves the Firewall Profiles of the System
+func (c *Client) GetFirewallProfiles(o RksOptions) ([]RksFirewallProfile, error) {
+	var fps []RksFirewallProfile
+	err := c.queryList("/firewallProfiles/query", newQuery("name"), o, &fps)
+	return fps, err
+}
+
+// GetFirewallProfile retrieves a Firewall Profile
+func (c *Client) GetFirewallProfile(id string) (RksFirewallProfile, error) {
+	var fp RksFirewallProfile
+	err := c.send("GET", fmt.Sprintf("/firewallProfiles/%s", id), RksOptions{}, nil, &fp)
+	return fp, err
+}
+
+// CreateFirewallProfile validates and creates a Firewall Profile returning its ID
+func (c *Client) CreateFirewallProfile(p RksFirewallProfile) (string, error) {
+	var created RksObject
+	err := c.submitPolicy("POST", "/firewallProfiles", p.Name, p, &created)
+	return created.ID, err
+}
+
+// UpdateFirewallProfile validates and modifies the fields of a Firewall Profile set in p
+func (c *Client) UpdateFirewallProfile(id string, p RksFirewallProfile) error {
+	return c.submitPolicy("PATCH", fmt.Sprintf("/firewallProfiles/%s", id), p.Name, p, nil)
+}
+
+// PatchFirewallProfile validates modified and PATCHes the fields of a Firewall
+// Profile that differ from original, the Profile as retrieved (see Patch)
+func (c *Client) PatchFirewallProfile(original, modified RksFirewallProfile) error {
+	return c.patchPolicy(fmt.Sprintf("/firewallProfiles/%s", original.ID), original, modified)
+}
+
+// DeleteFirewallProfile removes a Firewall Profile
+func (c *Client) DeleteFirewallProfile(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/firewallProfiles/%s", id), RksOptions{}, nil, nil)
+}
+
+// WlanPolicies the Access Control Policies a WLAN enforces
+// a nil Policy is not enforced
+type WlanPolicies struct {
+	ZoneID          string
+	WlanID          string
+	WlanName        string
+	L2Acl           *RksObject
+	L3Acp           *RksObject
+	DevicePolicy    *RksObject
+	FirewallProfile string
+}
+
+// GetWlanPolicies retrieves the Access Control Policies attached to each WLAN of a Zone
+func (c *Client) GetWlanPolicies(zoneID string) ([]WlanPolicies, error) {
+	wlans, err := c.GetZoneWlans(RksOptions{}, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	var out []WlanPolicies
+	for _, w := range wlans {
+		cfg, err := c.GetWlan(zoneID, w.ID)
+		if err != nil {
+			return out, fmt.Errorf("failed to get wlan %s: %v", w.Name, err)
+		}
+		wp := WlanPolicies{ZoneID: zoneID, WlanID: cfg.ID, WlanName: cfg.Name, FirewallProfile: cfg.FirewallProfileID}
+		if ac := cfg.AccessControl; ac != nil {
+			wp.L2Acl, wp.L3Acp, wp.DevicePolicy = ac.L2Acl, ac.L3Acp, ac.DevicePolicy
+		}
+		out = append(out, wp)
+	}
+	return out, nil
+}
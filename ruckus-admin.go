@@ -0,0 +1,239 @@
+package ruckus
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RksAdmin properties of a Controller Administrator Account
+type RksAdmin struct {
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+	FullName string `json:"fullName"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone"`
+	Title    string `json:"title"`
+	DomainID string `json:"domainId"`
+	// epoch milliseconds; 0 when the Account never logged in
+	CreateDateTime int64 `json:"createDateTime"`
+	LastLoginTime  int64 `json:"lastLoginTime"`
+}
+
+// RksAdminReq the fields of an Administrator that may be set on Create|Update
+type RksAdminReq struct {
+	UserName string `json:"userName,omitempty"`
+	Password string `json:"password,omitempty"`
+	FullName string `json:"fullName,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+	Title    string `json:"title,omitempty"`
+	DomainID string `json:"domainId,omitempty"`
+}
+
+// RksPermission the Access a User Group has to a Resource
+type RksPermission struct {
+	// ie AP|WLAN|ADMIN|MVNO_SYSTEM...
+	Resource string `json:"resource"`
+	// READ|MODIFY|FULL_ACCESS
+	Access string `json:"access"`
+}
+
+// RksResourceGroup a Domain|Zone a User Group is scoped to
+type RksResourceGroup struct {
+	ID string `json:"id"`
+	// DOMAIN|ZONE
+	Type string `json:"type"`
+}
+
+// RksUserGroup properties of an Administrator User Group
+type RksUserGroup struct {
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	Description    string             `json:"description"`
+	DomainID       string             `json:"domainId"`
+	Role           string             `json:"role"`
+	Users          []RksObject        `json:"users"`
+	Permissions    []RksPermission    `json:"permissions"`
+	ResourceGroups []RksResourceGroup `json:"resourceGroups"`
+}
+
+// RksUserGroupReq the fields of a User Group that may be set on Create|Update
+type RksUserGroupReq struct {
+	Name           string             `json:"name,omitempty"`
+	Description    string             `json:"description,omitempty"`
+	DomainID       string             `json:"domainId,omitempty"`
+	Role           string             `json:"role,omitempty"`
+	Permissions    []RksPermission    `json:"permissions,omitempty"`
+	ResourceGroups []RksResourceGroup `json:"resourceGroups,omitempty"`
+}
+
+// RksRole a predefined Administrator Role
+type RksRole struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GetAdmins retrieves the Administrator Accounts of the Controller
+func (c *Client) GetAdmins(o RksOptions) ([]RksAdmin, error) {
+	var admins []RksAdmin
+	err := c.queryList("/users/query", newQuery("userName"), o, &admins)
+	return admins, err
+}
+
+// GetAdmin retrieves an Administrator Account
+func (c *Client) GetAdmin(id string) (RksAdmin, error) {
+	var admin RksAdmin
+	err := c.send("GET", fmt.Sprintf("/users/%s", id), RksOptions{}, nil, &admin)
+	return admin, err
+}
+
+// CreateAdmin creates an Administrator Account returning its ID
+func (c *Client) CreateAdmin(a RksAdminReq) (string, error) {
+	var created RksObject
+	err := c.send("POST", "/users", RksOptions{}, a, &created)
+	return created.ID, err
+}
+
+// UpdateAdmin modifies the fields of an Administrator Account set in a
+func (c *Client) UpdateAdmin(id string, a RksAdminReq) error {
+	return c.update(fmt.Sprintf("/users/%s", id), a)
+}
+
+// PatchAdmin PATCHes the fields of an Administrator Account that differ
+// from original, the Account as retrieved (see Patch)
+func (c *Client) PatchAdmin(original, modified RksAdmin) error {
+	return c.patch(fmt.Sprintf("/users/%s", original.ID), original, modified)
+}
+
+// ResetAdminPassword sets a new Password on an Administrator Account
+func (c *Client) ResetAdminPassword(id, password string) error {
+	return c.UpdateAdmin(id, RksAdminReq{Password: password})
+}
+
+// DeleteAdmin removes an Administrator Account
+func (c *Client) DeleteAdmin(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/users/%s", id), RksOptions{}, nil, nil)
+}
+
+// GetUserGroups retrieves the Administrator User Groups of the Controller
+func (c *Client) GetUserGroups(o RksOptions) ([]RksUserGroup, error) {
+	var grps []RksUserGroup
+	err := c.queryList("/userGroups/query", newQuery("name"), o, &grps)
+	return grps, err
+}
+
+// GetUserGroup retrieves a User Group with its Users, Permissions and Resource Groups
+func (c *Client) GetUserGroup(id string) (RksUserGroup, error) {
+	var grp RksUserGroup
+	err := c.send("GET", fmt.Sprintf("/userGroups/%s", id), RksOptions{}, nil, &grp)
+	return grp, err
+}
+
+// CreateUserGroup creates a User Group returning its ID
+func (c *Client) CreateUserGroup(g RksUserGroupReq) (string, error) {
+	var created RksObject
+	err := c.send("POST", "/userGroups", RksOptions{}, g, &created)
+	return created.ID, err
+}
+
+// UpdateUserGroup modifies the fields of a User Group set in g
+func (c *Client) UpdateUserGroup(id string, g RksUserGroupReq) error {
+	return c.update(fmt.Sprintf("/userGroups/%s", id), g)
+}
+
+// PatchUserGroup PATCHes the fields of a User Group that differ from original,
+// the User Group as retrieved (see Patch)
+func (c *Client) PatchUserGroup(original, modified RksUserGroup) error {
+	return c.patch(fmt.Sprintf("/userGroups/%s", original.ID), original, modified)
+}
+
+// SetUserGroupScope replaces the Permissions and Resource Groups of a User Group
+// a nil list leaves that part unchanged; an empty one clears it
+func (c *Client) SetUserGroupScope(id string, perms []RksPermission, resources []RksResourceGroup) error {
+	body := struct {
+		Permissions    []RksPermission    `json:"permissions"`
+		ResourceGroups []RksResourceGroup `json:"resourceGroups"`
+	}{perms, resources}
+	return c.update(fmt.Sprintf("/userGroups/%s", id), body)
+}
+
+// DeleteUserGroup removes a User Group
+func (c *Client) DeleteUserGroup(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/userGroups/%s", id), RksOptions{}, nil, nil)
+}
+
+type userGroupMembersReq struct {
+	Users []RksObject `json:"users"`
+}
+
+func newMembersReq(userIDs []string) userGroupMembersReq {
+	req := userGroupMembersReq{}
+	for _, id := range userIDs {
+		req.Users = append(req.Users, RksObject{ID: id})
+	}
+	return req
+}
+
+// AddUsersToGroup assigns Administrator Accounts to a User Group
+func (c *Client) AddUsersToGroup(groupID string, userIDs ...string) error {
+	ep := fmt.Sprintf("/userGroups/%s/users", groupID)
+	return c.send("POST", ep, RksOptions{}, newMembersReq(userIDs), nil)
+}
+
+// RemoveUsersFromGroup removes Administrator Accounts from a User Group
+func (c *Client) RemoveUsersFromGroup(groupID string, userIDs ...string) error {
+	ep := fmt.Sprintf("/userGroups/%s/users", groupID)
+	return c.send("DELETE", ep, RksOptions{}, newMembersReq(userIDs), nil)
+}
+
+// GetRoles retrieves the predefined Roles a User Group may have
+// the Controller lists them under /userGroups/roles; there is no /roles resource
+func (c *Client) GetRoles() ([]RksRole, error) {
+	var roles []RksRole
+	err := c.getList("/userGroups/roles", RksOptions{}, &roles)
+	return roles, err
+}
+
+// AdminAudit the Login Activity of an Administrator Account
+type AdminAudit struct {
+	Admin         RksAdmin
+	NeverLoggedIn bool
+	LastLogin     time.Time
+	// the time since the last login (or since creation when never logged in)
+	Idle time.Duration
+}
+
+// AuditAdmins lists every Administrator Account by last login, least recent
+// first; Accounts that never logged in come first
+func (c *Client) AuditAdmins() ([]AdminAudit, error) {
+	admins, err := c.GetAdmins(RksOptions{})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	audits := make([]AdminAudit, 0, len(admins))
+	for _, a := range admins {
+		audit := AdminAudit{Admin: a, NeverLoggedIn: a.LastLoginTime == 0}
+		since := a.CreateDateTime
+		if !audit.NeverLoggedIn {
+			audit.LastLogin = msToTime(a.LastLoginTime)
+			since = a.LastLoginTime
+		}
+		if since > 0 {
+			audit.Idle = now.Sub(msToTime(since))
+		}
+		audits = append(audits, audit)
+	}
+	sort.SliceStable(audits, func(i, j int) bool {
+		if audits[i].NeverLoggedIn != audits[j].NeverLoggedIn {
+			return audits[i].NeverLoggedIn
+		}
+		return audits[i].Admin.LastLoginTime < audits[j].Admin.LastLoginTime
+	})
+	return audits, nil
+}
+
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
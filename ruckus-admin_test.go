@@ -0,0 +1,65 @@
+package ruckus
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuditAdmins(t *testing.T) {
+	f, c := newFakeController(t)
+	day := int64(24 * time.Hour / time.Millisecond)
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	f.on("POST /users/query", fmt.Sprintf(`{"list":[
+		{"id":"recent","createDateTime":%d,"lastLoginTime":%d},
+		{"id":"never","createDateTime":%d,"lastLoginTime":0},
+		{"id":"stale","createDateTime":%d,"lastLoginTime":%d},
+		{"id":"imported","createDateTime":0,"lastLoginTime":0}
+	]}`, now-30*day, now-day, now-10*day, now-90*day, now-60*day))
+
+	audits, err := c.AuditAdmins()
+	if err != nil {
+		t.Fatalf("AuditAdmins() = %v", err)
+	}
+	var ids []string
+	for _, a := range audits {
+		ids = append(ids, a.Admin.ID)
+	}
+	// never logged in first (in the order listed) then least recent login first
+	if want := []string{"never", "imported", "stale", "recent"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("AuditAdmins() = %v; want %v", ids, want)
+	}
+	tests := []struct {
+		never bool
+		idle  time.Duration
+	}{
+		{true, 10 * 24 * time.Hour},
+		{true, 0},
+		{false, 60 * 24 * time.Hour},
+		{false, 24 * time.Hour},
+	}
+	for i, tt := range tests {
+		a := audits[i]
+		if a.NeverLoggedIn != tt.never || a.LastLogin.IsZero() != tt.never {
+			t.Errorf("%s: NeverLoggedIn = %v, LastLogin = %v", a.Admin.ID, a.NeverLoggedIn, a.LastLogin)
+		}
+		// allow for the time the test takes
+		if a.Idle < tt.idle || a.Idle > tt.idle+time.Minute {
+			t.Errorf("%s: Idle = %v; want %v", a.Admin.ID, a.Idle, tt.idle)
+		}
+	}
+}
+
+func TestGetRoles(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("GET /userGroups/roles", `{"list":[{"name":"SUPER_ADMIN"},{"name":"READ_ONLY"}]}`)
+
+	roles, err := c.GetRoles()
+	if err != nil {
+		t.Fatalf("GetRoles() = %v", err)
+	}
+	if len(roles) != 2 || roles[0].Name != "SUPER_ADMIN" {
+		t.Errorf("GetRoles() = %+v", roles)
+	}
+}
@@ -0,0 +1,13 @@
+package ruckus
+
+// GetAlarms retrieves the Alarms of the Controller
+// state optionally restricts the Alarms to an AlarmState (Outstanding|Cleared...)
+func (c *Client) GetAlarms(o RksOptions, state string) ([]RksAlarm, error) {
+	q := newQuery("insertionTime")
+	if state != "" {
+		q.Filters = append(q.Filters, Mapper{Type: "ALARM_STATE", Value: state})
+	}
+	var alarms []RksAlarm
+	err := c.queryList("/alert/alarm/list", q, o, &alarms)
+	return alarms, err
+}
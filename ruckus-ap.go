@@ -31,7 +31,7 @@ func (c *Client) SetApNameAndGroup(apMacAddr, apName, zoneID, groupID string) {
 	}
 	c.addQS(req, RksOptions{})
 	req.Header.Add("Content-Type", "application/json;charset=UTF-8")
-	res, err := c.http.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		fmt.Printf("error in response: %v\n", err)
 		return
@@ -68,6 +68,7 @@ func (c *Client) GetAPs(o RksOptions) ([]RksAp, error) {
 			Page:          1,
 			Limit:         10000,
 		}
+		c.scopeQuery(&q, o)
 		qjson, _ := json.Marshal(&q)
 		body := strings.NewReader(string(qjson))
 		req, err := http.NewRequest("POST", c.BaseURL+"/query/ap", body)
@@ -76,7 +77,7 @@ func (c *Client) GetAPs(o RksOptions) ([]RksAp, error) {
 		}
 		c.addQS(req, o)
 
-		res, err := c.http.Do(req)
+		res, err := c.doRetry(req, true)
 		if err != nil {
 			return rksAps, fmt.Errorf("failed to get resp: %v", err)
 		}
@@ -111,6 +112,7 @@ func (c *Client) GetAp(macAddr string) (RksAp, error) {
 		Page:          1,
 		Limit:         2,
 	}
+	c.scopeQuery(&q, RksOptions{})
 	qjson, _ := json.Marshal(&q)
 	body := strings.NewReader(string(qjson))
 	req, err := http.NewRequest("POST", c.BaseURL+"/query/ap", body)
@@ -119,7 +121,7 @@ func (c *Client) GetAp(macAddr string) (RksAp, error) {
 	}
 	c.addQS(req, RksOptions{})
 
-	res, err := c.http.Do(req)
+	res, err := c.doRetry(req, true)
 	if err != nil {
 		return ap, fmt.Errorf("failed to get resp: %v", err)
 	}
@@ -144,7 +146,7 @@ func (c *Client) GetApGroupName(zoneID, groupID string) (string, error) {
 		return "", err
 	}
 	c.addQS(req, RksOptions{})
-	res, err := c.http.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return "", err
 	}
@@ -159,7 +161,7 @@ func (c *Client) GetApGroupName(zoneID, groupID string) (string, error) {
 
 // GetApGroups retrieves list of AP Group Names with IDs
 func (c *Client) GetApGroups(o RksOptions, zoneID string) ([]RksObject, error) {
-	if c.serviceTicket == "" {
+	if c.ticket() == "" {
 		return nil, fmt.Errorf(loginErr)
 	}
 	ep := fmt.Sprintf("/rkszones/%s/apgroups", zoneID)
@@ -168,7 +170,7 @@ func (c *Client) GetApGroups(o RksOptions, zoneID string) ([]RksObject, error) {
 		return nil, err
 	}
 	c.addQS(req, o)
-	res, err := c.http.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resp: %v", err)
 	}
@@ -178,89 +180,59 @@ func (c *Client) GetApGroups(o RksOptions, zoneID string) ([]RksObject, error) {
 	return grps.List, nil
 }
 
-// GetApIntf ...
+// GetAllApGroups retrieves the Names and IDs of every AP Group of a Zone
+// paging through the list however many AP Groups there are
+func (c *Client) GetAllApGroups(zoneID string) ([]RksObject, error) {
+	var grps []RksObject
+	err := c.getList(fmt.Sprintf("/rkszones/%s/apgroups", zoneID), RksOptions{}, &grps)
+	return grps, err
+}
+
+// GetApIntf retrieves the first LAN Port of an AP that is Up
+// (or the last Port when none are); see GetApLanPorts for every Port
+// an AP the Controller does not report the Ports of has an empty ApIntf
 func (c *Client) GetApIntf(macAddr string) (ApIntf, error) {
-	uri := fmt.Sprintf("https://%s:8443/wsg/api/scg/aps/%s", c.host, macAddr)
-	req, err := http.NewRequest("GET", uri, nil)
-	if err != nil {
-		return ApIntf{}, err
+	ports, err := c.GetApLanPorts(macAddr)
+	if _, ok := err.(*LanPortsUnavailableError); ok {
+		return ApIntf{}, nil
 	}
-	c.addQS(req, RksOptions{})
-	res, err := c.http.Do(req)
 	if err != nil {
 		return ApIntf{}, err
 	}
-	defer res.Body.Close()
-	type getApRESP struct {
-		Success bool `json:"success"`
-		Data    struct {
-			LanPorts []ApIntf `json:"lanPortStatus"`
-		} `json:"data"`
-	}
-	var apResp getApRESP
-	json.NewDecoder(res.Body).Decode(&apResp)
 	var apIntf ApIntf
-	if apResp.Success {
-		for _, port := range apResp.Data.LanPorts {
-			status := strings.ToLower(port.Status)
-			if status == "up" {
-				speedClean := strings.Replace(
-					port.Speed, "Up ", "", -1,
-				)
-				speedSplit := strings.Split(speedClean, " ")
-				apIntf = ApIntf{
-					MacAddr: port.MacAddr,
-					Speed:   speedSplit[0],
-					Duplex:  strings.ToUpper(speedSplit[1]),
-					Status:  status,
-				}
-				break
-			}
+	for _, port := range ports {
+		if port.LogicLinkUp {
 			apIntf = ApIntf{
 				MacAddr: port.MacAddr,
-				Speed:   status,
-				Status:  status,
+				Speed:   fmt.Sprintf("%dMbps", port.SpeedMbps),
+				Duplex:  strings.ToUpper(string(port.Duplex)),
+				Status:  "up",
 			}
+			break
+		}
+		status := strings.ToLower(port.LogicLink)
+		apIntf = ApIntf{
+			MacAddr: port.MacAddr,
+			Speed:   status,
+			Status:  status,
 		}
 	}
 	return apIntf, nil
 }
 
-// GetApLldp ...
+// GetApLldp retrieves the first LLDP Neighbor of an AP
+// see GetApLldpNeighbors for every Neighbor with full detail
 func (c *Client) GetApLldp(macAddr string) (ApLldp, error) {
-	uri := fmt.Sprintf("/aps/%s/apLldpNeighbors", macAddr)
-	req, err := c.genGetReq(uri)
-	if err != nil {
-		return ApLldp{}, err
-	}
-	c.addQS(req, RksOptions{})
-	res, err := c.http.Do(req)
-	if err != nil {
+	neighbors, err := c.GetApLldpNeighbors(macAddr)
+	if err != nil || len(neighbors) == 0 {
 		return ApLldp{}, err
 	}
-	defer res.Body.Close()
-	type getApRESP struct {
-		RksCommonReq
-		List []ApLldp `json:"list"`
-	}
-	var apResp getApRESP
-	var apLldp ApLldp
-	if err := json.NewDecoder(res.Body).Decode(&apResp); err != nil {
-		return apLldp, err
-	}
-	if apResp.TotalCount == 0 {
-		return apLldp, nil
-	}
-	for _, lldp := range apResp.List {
-		rmIntf := strings.Replace(lldp.RemoteIntf, "ifname ", "", -1)
-		apLldp = ApLldp{
-			RemoteHostname: lldp.RemoteHostname,
-			RemoteIntf:     rmIntf,
-			RemoteIP:       lldp.RemoteIP,
-		}
-		break
-	}
-	return apLldp, nil
+	n := neighbors[0]
+	return ApLldp{
+		RemoteHostname: n.SysName,
+		RemoteIntf:     n.PortID,
+		RemoteIP:       n.MgmtIP,
+	}, nil
 }
 
 // RebootAp ...
@@ -271,7 +243,7 @@ func (c *Client) RebootAp(macAddr string) (bool, error) {
 		return false, err
 	}
 	c.addQS(req, RksOptions{})
-	res, err := c.http.Do(req)
+	res, err := c.doRetry(req, false)
 	if err != nil {
 		return false, err
 	}
@@ -283,3 +255,101 @@ func (c *Client) RebootAp(macAddr string) (bool, error) {
 	json.NewDecoder(res.Body).Decode(&result)
 	return result.Success, nil
 }
+
+// GetApOperational retrieves the Operational Summary of an AP
+func (c *Client) GetApOperational(macAddr string) (ApOperational, error) {
+	uri := fmt.Sprintf("/aps/%s/operational/summary", macAddr)
+	req, err := c.genGetReq(uri)
+	if err != nil {
+		return ApOperational{}, err
+	}
+	c.addQS(req, RksOptions{})
+	res, err := c.do(req)
+	if err != nil {
+		return ApOperational{}, fmt.Errorf("failed to get resp: %v", err)
+	}
+	defer res.Body.Close()
+	var op ApOperational
+	if err := json.NewDecoder(res.Body).Decode(&op); err != nil {
+		return op, err
+	}
+	return op, nil
+}
+
+// RksApReq the fields of an AP that may be set on Update
+type RksApReq struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	ZoneID      string `json:"zoneId,omitempty"`
+	GroupID     string `json:"apGroupId,omitempty"`
+}
+
+// UpdateAp modifies the fields of an AP set in a
+// setting ZoneID|GroupID moves the AP to that Zone|AP Group
+func (c *Client) UpdateAp(macAddr string, a RksApReq) error {
+	return c.update(fmt.Sprintf("/aps/%s", macAddr), a)
+}
+
+// RksApConfig the Configuration of an AP
+type RksApConfig struct {
+	MacAddr     string `json:"mac"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ZoneID      string `json:"zoneId"`
+	GroupID     string `json:"apGroupId"`
+	Model       string `json:"model"`
+	Location    string `json:"location"`
+	// the Radio Settings the AP overrides
+	Wifi24 *RksApRadio `json:"wifi24,omitempty"`
+	Wifi50 *RksApRadio `json:"wifi50,omitempty"`
+	// the WLAN Group each Radio serves and whether it serves WLANs at all
+	WlanGroup24          *RksObject `json:"wlanGroup24,omitempty"`
+	WlanGroup50          *RksObject `json:"wlanGroup50,omitempty"`
+	WlanService24Enabled *bool      `json:"wlanService24Enabled,omitempty"`
+	WlanService50Enabled *bool      `json:"wlanService50Enabled,omitempty"`
+	rawDoc
+}
+
+type rksApConfig RksApConfig
+
+// UnmarshalJSON decodes an AP Configuration keeping the document (see MergeRaw)
+func (a *RksApConfig) UnmarshalJSON(data []byte) error {
+	var v rksApConfig
+	err := json.Unmarshal(data, &v)
+	v.keep(data)
+	*a = RksApConfig(v)
+	return err
+}
+
+// MarshalJSON encodes an AP Configuration merged into the document it was
+// retrieved as (see MergeRaw) so the fields it does not model are written back
+func (a RksApConfig) MarshalJSON() ([]byte, error) {
+	typed, err := json.Marshal(rksApConfig(a))
+	if err != nil {
+		return nil, err
+	}
+	return mergeRaw(a.Raw(), typed)
+}
+
+// GetApConfig retrieves the Configuration of an AP
+func (c *Client) GetApConfig(macAddr string) (RksApConfig, error) {
+	var cfg RksApConfig
+	err := c.send("GET", fmt.Sprintf("/aps/%s", macAddr), RksOptions{}, nil, &cfg)
+	return cfg, err
+}
+
+// PatchAp PATCHes the fields of the Configuration of an AP that differ
+// from original, the Configuration as retrieved (see Patch)
+func (c *Client) PatchAp(original, modified RksApConfig) error {
+	return c.patch(fmt.Sprintf("/aps/%s", original.MacAddr), original, modified)
+}
+
+// SaveAp replaces the Configuration of an AP with a merged into the document
+// it was retrieved as (see MergeRaw) so fields a does not model are kept
+func (c *Client) SaveAp(a RksApConfig) error {
+	doc, err := MergeRaw(a)
+	if err != nil {
+		return err
+	}
+	return c.send("PUT", fmt.Sprintf("/aps/%s", a.MacAddr), RksOptions{}, doc, nil)
+}
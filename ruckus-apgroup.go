@@ -0,0 +1,90 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RksApGroup properties of an AP Group
+type RksApGroup struct {
+	ID          string `json:"id,omitempty"`
+	ZoneID      string `json:"zoneId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// the Radio Settings the AP Group overrides
+	Wifi24 *RksApRadio `json:"wifi24,omitempty"`
+	Wifi50 *RksApRadio `json:"wifi50,omitempty"`
+	// the WLAN Group each Radio serves and whether it serves WLANs at all
+	WlanGroup24          *RksObject `json:"wlanGroup24,omitempty"`
+	WlanGroup50          *RksObject `json:"wlanGroup50,omitempty"`
+	WlanService24Enabled *bool      `json:"wlanService24Enabled,omitempty"`
+	WlanService50Enabled *bool      `json:"wlanService50Enabled,omitempty"`
+	rawDoc
+}
+
+type rksApGroup RksApGroup
+
+// UnmarshalJSON decodes an AP Group keeping the document (see MergeRaw)
+func (g *RksApGroup) UnmarshalJSON(data []byte) error {
+	var v rksApGroup
+	err := json.Unmarshal(data, &v)
+	v.keep(data)
+	*g = RksApGroup(v)
+	return err
+}
+
+// MarshalJSON encodes an AP Group merged into the document it was retrieved as
+// (see MergeRaw) so the fields it does not model are written back
+func (g RksApGroup) MarshalJSON() ([]byte, error) {
+	typed, err := json.Marshal(rksApGroup(g))
+	if err != nil {
+		return nil, err
+	}
+	return mergeRaw(g.Raw(), typed)
+}
+
+// GetApGroup retrieves an AP Group of a Zone
+func (c *Client) GetApGroup(zoneID, id string) (RksApGroup, error) {
+	var grp RksApGroup
+	ep := fmt.Sprintf("/rkszones/%s/apgroups/%s", zoneID, id)
+	err := c.send("GET", ep, RksOptions{}, nil, &grp)
+	return grp, err
+}
+
+// CreateApGroup creates an AP Group in a Zone returning its ID
+func (c *Client) CreateApGroup(zoneID string, g RksApGroup) (string, error) {
+	var created RksObject
+	ep := fmt.Sprintf("/rkszones/%s/apgroups", zoneID)
+	err := c.send("POST", ep, RksOptions{}, g, &created)
+	return created.ID, err
+}
+
+// UpdateApGroup modifies the fields of an AP Group set in g
+func (c *Client) UpdateApGroup(zoneID, id string, g RksApGroup) error {
+	ep := fmt.Sprintf("/rkszones/%s/apgroups/%s", zoneID, id)
+	return c.update(ep, g)
+}
+
+// PatchApGroup PATCHes the fields of an AP Group that differ from original,
+// the AP Group as retrieved (see Patch); unlike UpdateApGroup it clears
+// the fields modified leaves empty (ie the Radio overrides)
+func (c *Client) PatchApGroup(zoneID string, original, modified RksApGroup) error {
+	return c.patch(fmt.Sprintf("/rkszones/%s/apgroups/%s", zoneID, original.ID), original, modified)
+}
+
+// SaveApGroup replaces an AP Group with g merged into the document it
+// was retrieved as (see MergeRaw) so fields g does not model are kept
+func (c *Client) SaveApGroup(zoneID string, g RksApGroup) error {
+	doc, err := MergeRaw(g)
+	if err != nil {
+		return err
+	}
+	ep := fmt.Sprintf("/rkszones/%s/apgroups/%s", zoneID, g.ID)
+	return c.send("PUT", ep, RksOptions{}, doc, nil)
+}
+
+// DeleteApGroup removes an AP Group; its APs are moved to the default Group
+func (c *Client) DeleteApGroup(zoneID, id string) error {
+	ep := fmt.Sprintf("/rkszones/%s/apgroups/%s", zoneID, id)
+	return c.send("DELETE", ep, RksOptions{}, nil, nil)
+}
@@ -0,0 +1,295 @@
+package ruckus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// pollInterval how often progress is checked while waiting (backups, upgrades...)
+var pollInterval = 10 * time.Second
+
+// RksConfigBackup a Configuration Backup stored on the Controller
+type RksConfigBackup struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+	ModelName   string `json:"modelName"`
+	FileSize    int64  `json:"fileSize"`
+	// epoch milliseconds
+	CreatedOn int64 `json:"createdOn"`
+}
+
+// Created the time the Backup was taken
+func (b RksConfigBackup) Created() time.Time {
+	return time.Unix(0, b.CreatedOn*int64(time.Millisecond))
+}
+
+// RksClusterState the State of the Controller Cluster
+type RksClusterState struct {
+	ClusterName     string `json:"clusterName"`
+	ClusterRole     string `json:"clusterRole"`
+	ClusterState    string `json:"clusterState"`
+	CurrentNodeID   string `json:"currentNodeId"`
+	CurrentNodeName string `json:"currentNodeName"`
+	Nodes           []struct {
+		NodeID    string `json:"nodeId"`
+		NodeName  string `json:"nodeName"`
+		NodeState string `json:"nodeState"`
+	} `json:"nodeStateList"`
+}
+
+// InService reports whether the Cluster is serving (no backup|restore|upgrade running)
+func (s RksClusterState) InService() bool {
+	return strings.EqualFold(s.ClusterState, "In_Service")
+}
+
+// CreateConfigBackup starts a Configuration Backup on the Controller
+// see BackupConfig to also wait for it to complete
+func (c *Client) CreateConfigBackup() error {
+	return c.send("POST", "/configuration/backup", RksOptions{}, nil, nil)
+}
+
+// ListConfigBackups retrieves the Configuration Backups stored on the Controller
+func (c *Client) ListConfigBackups() ([]RksConfigBackup, error) {
+	var backups []RksConfigBackup
+	err := c.getList("/configuration", RksOptions{}, &backups)
+	return backups, err
+}
+
+// DownloadConfigBackup streams a Configuration Backup to w
+func (c *Client) DownloadConfigBackup(id string, w io.Writer) error {
+	if c.ticket() == "" {
+		return fmt.Errorf(loginErr)
+	}
+	req, err := c.genGetReq("/configuration/download")
+	if err != nil {
+		return err
+	}
+	c.addQS(req, RksOptions{})
+	q := req.URL.Query()
+	q.Add("backupUUID", id)
+	req.URL.RawQuery = q.Encode()
+	res, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get resp: %v", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newRksError(res)
+	}
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return fmt.Errorf("failed to download backup %s: %v", id, err)
+	}
+	return nil
+}
+
+// DeleteConfigBackup removes a Configuration Backup from the Controller
+func (c *Client) DeleteConfigBackup(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/configuration/%s", id), RksOptions{}, nil, nil)
+}
+
+// RestoreConfigBackup restores the Controller to a Configuration Backup
+// the Cluster restarts its services; see WaitForClusterOperation
+func (c *Client) RestoreConfigBackup(id string) error {
+	return c.send("POST", fmt.Sprintf("/configuration/restore/%s", id), RksOptions{}, nil, nil)
+}
+
+// BackupConfig creates a Configuration Backup and waits up to timeout for it to complete
+func (c *Client) BackupConfig(ctx context.Context, timeout time.Duration) (RksConfigBackup, error) {
+	before, err := c.ListConfigBackups()
+	if err != nil {
+		return RksConfigBackup{}, err
+	}
+	if err := c.CreateConfigBackup(); err != nil {
+		return RksConfigBackup{}, err
+	}
+	return c.WaitForConfigBackup(ctx, before, timeout)
+}
+
+// WaitForConfigBackup polls the Controller until a Configuration Backup
+// not in before is complete or timeout elapses: it is listed with its
+// file written and the Cluster is back In Service
+func (c *Client) WaitForConfigBackup(ctx context.Context, before []RksConfigBackup, timeout time.Duration) (RksConfigBackup, error) {
+	known := make(map[string]bool, len(before))
+	for _, b := range before {
+		known[b.ID] = true
+	}
+	var created RksConfigBackup
+	err := poll(ctx, timeout, func() (bool, error) {
+		backups, err := c.ListConfigBackups()
+		if err != nil {
+			return false, err
+		}
+		created = RksConfigBackup{}
+		for _, b := range backups {
+			if !known[b.ID] {
+				created = b
+				break
+			}
+		}
+		if created.ID == "" || created.FileSize == 0 {
+			return false, nil
+		}
+		st, err := c.GetClusterState()
+		if err != nil {
+			return false, nil
+		}
+		return st.InService(), nil
+	})
+	if err != nil {
+		return created, fmt.Errorf("waiting for configuration backup: %v", err)
+	}
+	return created, nil
+}
+
+// CreateClusterBackup starts a Backup of the whole Cluster (all Nodes)
+// see BackupCluster to also wait for it to complete
+func (c *Client) CreateClusterBackup() error {
+	return c.send("POST", "/cluster/backup", RksOptions{}, nil, nil)
+}
+
+// GetClusterState retrieves the State of the Controller Cluster
+func (c *Client) GetClusterState() (RksClusterState, error) {
+	var st RksClusterState
+	err := c.send("GET", "/cluster/state", RksOptions{}, nil, &st)
+	return st, err
+}
+
+// RksClusterBackup a Backup of the whole Cluster stored on the Controller
+type RksClusterBackup struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	// epoch milliseconds
+	CreatedOn int64 `json:"createdOn"`
+}
+
+// ListClusterBackups retrieves the Cluster Backups stored on the Controller
+func (c *Client) ListClusterBackups() ([]RksClusterBackup, error) {
+	var backups []RksClusterBackup
+	err := c.getList("/cluster", RksOptions{}, &backups)
+	return backups, err
+}
+
+// BackupCluster creates a Cluster Backup and waits up to timeout for it to complete
+func (c *Client) BackupCluster(ctx context.Context, timeout time.Duration) (RksClusterState, error) {
+	before, err := c.ListClusterBackups()
+	if err != nil {
+		return RksClusterState{}, err
+	}
+	if err := c.CreateClusterBackup(); err != nil {
+		return RksClusterState{}, err
+	}
+	return c.WaitForClusterBackup(ctx, before, timeout)
+}
+
+// WaitForClusterBackup polls the Controller until a Cluster Backup not in
+// before is listed and the Cluster is back In Service, or timeout elapses.
+// Unlike the Cluster State the list also shows a Backup that completed
+// between two polls
+func (c *Client) WaitForClusterBackup(ctx context.Context, before []RksClusterBackup, timeout time.Duration) (RksClusterState, error) {
+	known := make(map[string]bool, len(before))
+	for _, b := range before {
+		known[b.ID] = true
+	}
+	var st RksClusterState
+	err := poll(ctx, timeout, func() (bool, error) {
+		backups, err := c.ListClusterBackups()
+		if err != nil {
+			// the Controller may not answer while the Backup runs
+			return false, nil
+		}
+		created := false
+		for _, b := range backups {
+			if !known[b.ID] {
+				created = true
+				break
+			}
+		}
+		if !created {
+			return false, nil
+		}
+		if st, err = c.GetClusterState(); err != nil {
+			return false, nil
+		}
+		return st.InService(), nil
+	})
+	if err != nil {
+		return st, fmt.Errorf("waiting for cluster backup: %v", err)
+	}
+	return st, nil
+}
+
+// clusterStartPolls how many polls a Cluster Backup|Restore is given to
+// take the Cluster out of Service; a Cluster still In Service after them
+// completed the operation between two polls
+var clusterStartPolls = 3
+
+// WaitForClusterOperation waits for a Cluster Backup|Restore just started to
+// complete: first for the Cluster to leave In Service (or stop answering)
+// then for it to be back, both within timeout. The Cluster still reports
+// In Service right after the Backup|Restore is requested; one that never
+// leaves it within clusterStartPolls polls is taken as done
+// see WaitForClusterBackup to wait for a Cluster Backup without guessing
+func (c *Client) WaitForClusterOperation(ctx context.Context, timeout time.Duration) (RksClusterState, error) {
+	deadline := time.Now().Add(timeout)
+	var st RksClusterState
+	polls := 0
+	err := poll(ctx, timeout, func() (bool, error) {
+		var err error
+		polls++
+		st, err = c.GetClusterState()
+		return err != nil || !st.InService() || polls >= clusterStartPolls, nil
+	})
+	if err != nil {
+		return st, fmt.Errorf("waiting for cluster operation to start: %v", err)
+	}
+	if st.InService() {
+		return st, nil
+	}
+	return c.WaitForClusterInService(ctx, time.Until(deadline))
+}
+
+// WaitForClusterInService polls the Cluster State until it is In Service
+// or timeout elapses; see WaitForClusterOperation to wait for a Backup|Restore
+func (c *Client) WaitForClusterInService(ctx context.Context, timeout time.Duration) (RksClusterState, error) {
+	var st RksClusterState
+	err := poll(ctx, timeout, func() (bool, error) {
+		var err error
+		st, err = c.GetClusterState()
+		if err != nil {
+			// the Controller may not answer while its services restart
+			return false, nil
+		}
+		return st.InService(), nil
+	})
+	if err != nil {
+		return st, fmt.Errorf("waiting for cluster (state %s): %v", st.ClusterState, err)
+	}
+	return st, nil
+}
+
+// poll calls done every pollInterval until it reports true,
+// returns an error, ctx is done or timeout elapses
+func poll(ctx context.Context, timeout time.Duration, done func() (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	tick := time.NewTicker(pollInterval)
+	defer tick.Stop()
+	for {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick.C:
+		}
+	}
+}
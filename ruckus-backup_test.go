@@ -0,0 +1,113 @@
+package ruckus
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBackupClusterWaitsForTheBackup(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = time.Millisecond
+
+	f, c := newFakeController(t)
+	f.on("POST /cluster/backup", ``)
+	f.on("GET /cluster",
+		`{"list":[{"id":"old"}]}`,
+		`{"list":[{"id":"old"}]}`,
+		`{"list":[{"id":"old"},{"id":"new"}]}`)
+	f.on("GET /cluster/state",
+		`{"clusterState":"Backup_In_Progress"}`,
+		`{"clusterState":"In_Service"}`)
+
+	st, err := c.BackupCluster(context.Background(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.received("POST /cluster/backup")) != 1 {
+		t.Fatal("no backup was requested")
+	}
+	if polls := len(f.received("GET /cluster/state")); !st.InService() || polls != 2 {
+		t.Errorf("returned after %d state polls (state %s); want 2", polls, st.ClusterState)
+	}
+}
+
+func TestBackupClusterQuickBackup(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = time.Millisecond
+
+	f, c := newFakeController(t)
+	f.on("POST /cluster/backup", ``)
+	// done before the first poll: the Cluster never leaves In Service
+	f.on("GET /cluster",
+		`{"list":[{"id":"old"}]}`,
+		`{"list":[{"id":"old"},{"id":"new"}]}`)
+	f.on("GET /cluster/state", `{"clusterState":"In_Service"}`)
+
+	st, err := c.BackupCluster(context.Background(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls := len(f.received("GET /cluster")); !st.InService() || polls != 2 {
+		t.Errorf("returned after %d list polls (state %s); want 2", polls, st.ClusterState)
+	}
+}
+
+func TestWaitForClusterOperationWaitsForTheRestore(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = time.Millisecond
+
+	f, c := newFakeController(t)
+	// the Cluster keeps reporting In Service for a while after the request
+	f.on("GET /cluster/state",
+		`{"clusterState":"In_Service"}`,
+		`{"clusterState":"In_Service"}`,
+		`{"clusterState":"Restore_In_Progress"}`,
+		`{"clusterState":"Restore_In_Progress"}`,
+		`{"clusterState":"In_Service"}`)
+
+	st, err := c.WaitForClusterOperation(context.Background(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls := len(f.received("GET /cluster/state")); !st.InService() || polls != 5 {
+		t.Errorf("returned after %d polls (state %s); want 5", polls, st.ClusterState)
+	}
+}
+
+func TestWaitForClusterOperationMissed(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = time.Millisecond
+
+	f, c := newFakeController(t)
+	// the operation completed before the first poll
+	f.on("GET /cluster/state", `{"clusterState":"In_Service"}`)
+
+	st, err := c.WaitForClusterOperation(context.Background(), time.Second)
+	if err != nil {
+		t.Fatalf("WaitForClusterOperation() = %v; want the operation taken as done", err)
+	}
+	if polls := len(f.received("GET /cluster/state")); !st.InService() || polls != clusterStartPolls {
+		t.Errorf("returned after %d polls (state %s); want %d", polls, st.ClusterState, clusterStartPolls)
+	}
+}
+
+func TestWaitForConfigBackupWaitsForTheFile(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = time.Millisecond
+
+	f, c := newFakeController(t)
+	f.on("GET /configuration",
+		`{"list":[{"id":"old","fileSize":10}]}`,
+		`{"list":[{"id":"old","fileSize":10},{"id":"new","fileSize":0}]}`,
+		`{"list":[{"id":"old","fileSize":10},{"id":"new","fileSize":20}]}`)
+	f.on("GET /cluster/state", `{"clusterState":"In_Service"}`)
+
+	b, err := c.WaitForConfigBackup(context.Background(), []RksConfigBackup{{ID: "old"}}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != "new" || b.FileSize != 20 {
+		t.Errorf("WaitForConfigBackup() = %+v; want the completed backup new", b)
+	}
+}
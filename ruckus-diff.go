@@ -0,0 +1,238 @@
+package ruckus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DiffZones returns the differences between two Zones field by field
+// Paths follow the JSON names of the fields (ie "wifi24.channelRange")
+func DiffZones(a, b RksZone) ([]FieldDiff, error) {
+	return diffJSON(a, b)
+}
+
+// ZoneCompliance the outcome of checking a Zone against a Baseline
+type ZoneCompliance struct {
+	ZoneID    string      `json:"zoneId"`
+	ZoneName  string      `json:"zoneName"`
+	Compliant bool        `json:"compliant"`
+	Diffs     []FieldDiff `json:"diffs,omitempty"`
+}
+
+// CheckZoneCompliance compares zone with baseline ignoring ignorePaths
+// an ignored path also ignores everything beneath it ("wifi24" ignores
+// "wifi24.txPower"); Old holds the baseline value and New the zone value
+func CheckZoneCompliance(zone, baseline RksZone, ignorePaths []string) (ZoneCompliance, error) {
+	zc := ZoneCompliance{ZoneID: zone.ID, ZoneName: zone.Name}
+	diffs, err := DiffZones(baseline, zone)
+	if err != nil {
+		return zc, fmt.Errorf("failed to compare zone %s: %v", zone.Name, err)
+	}
+	for _, d := range diffs {
+		if !pathIgnored(d.Path, ignorePaths) {
+			zc.Diffs = append(zc.Diffs, d)
+		}
+	}
+	zc.Compliant = len(zc.Diffs) == 0
+	return zc, nil
+}
+
+func pathIgnored(path string, ignorePaths []string) bool {
+	for _, p := range ignorePaths {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// diffJSON compares the JSON documents a and b encode to
+func diffJSON(a, b interface{}) ([]FieldDiff, error) {
+	av, err := toJSONValue(a)
+	if err != nil {
+		return nil, err
+	}
+	bv, err := toJSONValue(b)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []FieldDiff
+	diffValues("", av, bv, &diffs)
+	return diffs, nil
+}
+
+// toJSONValue converts v to its generic JSON form (maps, slices, json.Number...)
+func toJSONValue(v interface{}) (interface{}, error) {
+	var d []byte
+	switch raw := v.(type) {
+	case json.RawMessage:
+		d = raw
+	case []byte:
+		d = raw
+	default:
+		var err error
+		if d, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(d))
+	dec.UseNumber()
+	var out interface{}
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode json: %v", err)
+	}
+	return out, nil
+}
+
+func diffValues(path string, a, b interface{}, diffs *[]FieldDiff) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool)
+		for k := range av {
+			keys[k] = true
+		}
+		for k := range bv {
+			keys[k] = true
+		}
+		var sorted []string
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffValues(joinPath(path, k), av[k], bv[k], diffs)
+		}
+		return
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		// Lists of identified objects are compared by ID, other lists of
+		// objects element by element; lists of scalars (ie channel ranges) as a whole
+		if ok && keyedList(av) && keyedList(bv) && (len(av) > 0 || len(bv) > 0) {
+			diffKeyed(path, av, bv, diffs)
+			return
+		}
+		if ok && len(av) == len(bv) && hasObjects(av) {
+			for i := range av {
+				diffValues(fmt.Sprintf("%s[%d]", path, i), av[i], bv[i], diffs)
+			}
+			return
+		}
+	}
+	// null and an empty list|object are equivalent to the Controller
+	if isEmptyJSON(a) && isEmptyJSON(b) {
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, FieldDiff{Path: path, Old: plainJSON(a), New: plainJSON(b)})
+	}
+}
+
+// listKeys the fields identifying the objects of a list
+var listKeys = []string{"id", "apMac", "mac"}
+
+func elementKey(v interface{}) (string, bool) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	for _, k := range listKeys {
+		if id, ok := obj[k].(string); ok && id != "" {
+			return k + "=" + id, true
+		}
+	}
+	return "", false
+}
+
+func keyedList(l []interface{}) bool {
+	for _, v := range l {
+		if _, ok := elementKey(v); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func diffKeyed(path string, a, b []interface{}, diffs *[]FieldDiff) {
+	av := make(map[string]interface{}, len(a))
+	bv := make(map[string]interface{}, len(b))
+	var keys []string
+	for _, v := range a {
+		k, _ := elementKey(v)
+		if _, ok := av[k]; !ok {
+			keys = append(keys, k)
+		}
+		av[k] = v
+	}
+	for _, v := range b {
+		k, _ := elementKey(v)
+		if _, ok := av[k]; !ok {
+			keys = append(keys, k)
+		}
+		bv[k] = v
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		diffValues(fmt.Sprintf("%s[%s]", path, k), av[k], bv[k], diffs)
+	}
+}
+
+func isEmptyJSON(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(t) == 0
+	case map[string]interface{}:
+		return len(t) == 0
+	}
+	return false
+}
+
+func hasObjects(l []interface{}) bool {
+	for _, v := range l {
+		if _, ok := v.(map[string]interface{}); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// plainJSON converts json.Numbers back to int64|float64 for readable output
+func plainJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i := range t {
+			out[i] = plainJSON(t[i])
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k := range t {
+			out[k] = plainJSON(t[k])
+		}
+		return out
+	}
+	return v
+}
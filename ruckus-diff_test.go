@@ -0,0 +1,110 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func zoneFrom(t *testing.T, doc string) RksZone {
+	t.Helper()
+	var z RksZone
+	if err := json.Unmarshal([]byte(doc), &z); err != nil {
+		t.Fatalf("failed to decode %s: %v", doc, err)
+	}
+	return z
+}
+
+func TestDiffZones(t *testing.T) {
+	a := zoneFrom(t, `{"id":"z1","name":"campus","wifi24":{"txPower":"Full","channelRange":[1,6,11]},"mesh":null}`)
+	b := zoneFrom(t, `{"id":"z1","name":"campus","wifi24":{"txPower":"-3dB","channelRange":[1,6]},"mesh":{}}`)
+
+	diffs, err := DiffZones(a, b)
+	if err != nil {
+		t.Fatalf("DiffZones() = %v", err)
+	}
+	want := []FieldDiff{
+		{Path: "wifi24.channelRange", Old: []interface{}{int64(1), int64(6), int64(11)}, New: []interface{}{int64(1), int64(6)}},
+		{Path: "wifi24.txPower", Old: "Full", New: "-3dB"},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("DiffZones() = %#v; want %#v", diffs, want)
+	}
+}
+
+func TestDiffZonesUnencodable(t *testing.T) {
+	var a RksZone
+	// a document no longer valid JSON cannot be merged into
+	a.keep([]byte(`{"id":`))
+	if _, err := DiffZones(a, RksZone{}); err == nil {
+		t.Error("DiffZones() = nil; want an error")
+	}
+	if _, err := CheckZoneCompliance(RksZone{}, a, nil); err == nil {
+		t.Error("CheckZoneCompliance() = nil; want an error")
+	}
+}
+
+func TestCheckZoneCompliance(t *testing.T) {
+	baseline := zoneFrom(t, `{"id":"b","name":"baseline","countryCode":"US","wifi24":{"txPower":"Full"},"dfsChannelEnabled":true}`)
+	tests := []struct {
+		name      string
+		zone      string
+		ignore    []string
+		compliant bool
+		paths     []string
+	}{
+		{
+			name:      "identical",
+			zone:      `{"id":"z1","name":"a","countryCode":"US","wifi24":{"txPower":"Full"},"dfsChannelEnabled":true}`,
+			ignore:    []string{"id", "name"},
+			compliant: true,
+		},
+		{
+			name:   "drifted",
+			zone:   `{"id":"z1","name":"a","countryCode":"CA","wifi24":{"txPower":"-6dB","channel":6},"dfsChannelEnabled":true}`,
+			ignore: []string{"id", "name"},
+			paths:  []string{"countryCode", "wifi24.channel", "wifi24.txPower"},
+		},
+		{
+			name:   "subtree ignored",
+			zone:   `{"id":"z1","name":"a","countryCode":"CA","wifi24":{"txPower":"-6dB","channel":6},"dfsChannelEnabled":false}`,
+			ignore: []string{"id", "name", "wifi24"},
+			paths:  []string{"countryCode", "dfsChannelEnabled"},
+		},
+		{
+			name:   "prefix of a name is not a parent",
+			zone:   `{"id":"z1","name":"a","countryCode":"US","wifi24":{"txPower":"Full"},"dfsChannelEnabled":true}`,
+			ignore: []string{"i", "nam"},
+			paths:  []string{"id", "name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zc, err := CheckZoneCompliance(zoneFrom(t, tt.zone), baseline, tt.ignore)
+			if err != nil {
+				t.Fatalf("CheckZoneCompliance() = %v", err)
+			}
+			if zc.ZoneID != "z1" || zc.Compliant != tt.compliant {
+				t.Errorf("zone %q compliant = %v; want %v", zc.ZoneID, zc.Compliant, tt.compliant)
+			}
+			var paths []string
+			for _, d := range zc.Diffs {
+				paths = append(paths, d.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("diffs = %v; want %v", paths, tt.paths)
+			}
+		})
+	}
+}
+
+func TestCheckZoneComplianceBaselineIsOld(t *testing.T) {
+	baseline := zoneFrom(t, `{"countryCode":"US"}`)
+	zc, err := CheckZoneCompliance(zoneFrom(t, `{"countryCode":"CA"}`), baseline, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zc.Diffs) != 1 || zc.Diffs[0].Old != "US" || zc.Diffs[0].New != "CA" {
+		t.Errorf("diffs = %+v; want countryCode US => CA", zc.Diffs)
+	}
+}
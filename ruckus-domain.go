@@ -0,0 +1,100 @@
+package ruckus
+
+import "fmt"
+
+// RksDomain properties of a (Partner) Domain along with its Subdomains
+type RksDomain struct {
+	ID             string      `json:"id"`
+	Name           string      `json:"name"`
+	Description    string      `json:"description"`
+	DomainType     string      `json:"domainType"`
+	ParentDomainID string      `json:"parentDomainId"`
+	SubDomainCount int         `json:"subDomainCount"`
+	ApCount        int         `json:"apCount"`
+	ZoneCount      int         `json:"zoneCount"`
+	CreatedBy      string      `json:"createdByUserName"`
+	SubDomains     []RksDomain `json:"subDomains,omitempty"`
+}
+
+// RksDomainReq the fields of a Domain that may be set on Create|Update
+type RksDomainReq struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// REGULAR|PARTNER
+	DomainType     string `json:"domainType,omitempty"`
+	ParentDomainID string `json:"parentDomainId,omitempty"`
+}
+
+// GetDomains retrieves the Domains of the Client (or o.DomainID)
+// with every level of their Subdomains filled in
+func (c *Client) GetDomains(o RksOptions) ([]RksDomain, error) {
+	var domains []RksDomain
+	if err := c.getList("/domains", o, &domains); err != nil {
+		return nil, err
+	}
+	for i := range domains {
+		if err := c.fillSubDomains(&domains[i]); err != nil {
+			return domains, err
+		}
+	}
+	return domains, nil
+}
+
+func (c *Client) fillSubDomains(d *RksDomain) error {
+	var subs []RksDomain
+	if err := c.getList(fmt.Sprintf("/domains/%s/subdomain", d.ID), RksOptions{}, &subs); err != nil {
+		return fmt.Errorf("failed to get subdomains of %s: %v", d.Name, err)
+	}
+	for i := range subs {
+		if err := c.fillSubDomains(&subs[i]); err != nil {
+			return err
+		}
+	}
+	d.SubDomains = subs
+	return nil
+}
+
+// FlattenDomains returns every Domain of a tree (as GetDomains returns) parents first
+func FlattenDomains(domains []RksDomain) []RksDomain {
+	var flat []RksDomain
+	for _, d := range domains {
+		subs := d.SubDomains
+		d.SubDomains = nil
+		flat = append(flat, d)
+		flat = append(flat, FlattenDomains(subs)...)
+	}
+	return flat
+}
+
+// CreateDomain creates a Domain returning its ID
+func (c *Client) CreateDomain(d RksDomainReq) (string, error) {
+	var created RksObject
+	err := c.send("POST", "/domains", RksOptions{}, d, &created)
+	return created.ID, err
+}
+
+// UpdateDomain modifies the fields of a Domain set in d
+func (c *Client) UpdateDomain(id string, d RksDomainReq) error {
+	return c.update(fmt.Sprintf("/domains/%s", id), d)
+}
+
+// PatchDomain PATCHes the fields of a Domain that differ from original,
+// the Domain as retrieved (see Patch)
+func (c *Client) PatchDomain(original, modified RksDomain) error {
+	return c.patch(fmt.Sprintf("/domains/%s", original.ID), original, modified)
+}
+
+// DeleteDomain removes a Domain
+func (c *Client) DeleteDomain(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/domains/%s", id), RksOptions{}, nil, nil)
+}
+
+// InDomain returns a view of the Client scoped to a Domain
+// every call made through it carries the Domain ID (unless RksOptions sets
+// another), as a DOMAIN Filter for Query endpoints (ie GetAPs);
+// the view shares the session, RetryPolicy and Limiter of c
+func (c *Client) InDomain(id string) *Client {
+	scoped := *c
+	scoped.domainID = id
+	return &scoped
+}
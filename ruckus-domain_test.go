@@ -0,0 +1,39 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInDomainScopesQueries(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("POST /query/ap", `{"list":[]}`)
+	f.on("POST /query/wlan", `{"list":[]}`)
+
+	if _, err := c.InDomain("d1").GetAPs(RksOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.InDomain("d1").GetWlans(RksOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetWlans(RksOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.received("")
+	if len(reqs) != 3 {
+		t.Fatalf("%d queries sent; want 3", len(reqs))
+	}
+	want := Mapper{Type: "DOMAIN", Value: "d1"}
+	for i, r := range reqs {
+		var q RksQuery
+		if err := json.Unmarshal([]byte(r.Body), &q); err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case i < 2 && (len(q.Filters) != 1 || q.Filters[0] != want):
+			t.Errorf("query %d filters = %+v; want %+v", i, q.Filters, want)
+		case i == 2 && len(q.Filters) != 0:
+			t.Errorf("unscoped query filters = %+v; want none", q.Filters)
+		}
+	}
+}
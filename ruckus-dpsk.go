@@ -0,0 +1,135 @@
+package ruckus
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RksDpsk a Dynamic Pre-Shared Key of a WLAN
+type RksDpsk struct {
+	ID          string `json:"id"`
+	UserName    string `json:"userName"`
+	Passphrase  string `json:"passphrase"`
+	VlanID      int    `json:"vlanId"`
+	GroupDpskID string `json:"groupDpskId"`
+	// epoch milliseconds; ExpirationDateTime is 0 when the Key never expires
+	CreationDateTime   int64 `json:"creationDateTime"`
+	ExpirationDateTime int64 `json:"expirationDateTime"`
+}
+
+// Expires the time the Key expires; the zero Time when it never does
+func (d RksDpsk) Expires() time.Time {
+	if d.ExpirationDateTime == 0 {
+		return time.Time{}
+	}
+	return msToTime(d.ExpirationDateTime)
+}
+
+// DpskBatch describes a batch of Keys to generate
+type DpskBatch struct {
+	Count int
+	// the Keys are named UserNamePrefix followed by a sequence number
+	UserNamePrefix string
+	// 0 keeps the VLAN of the WLAN
+	VlanID int
+	// the zero Time uses the Expiration set on the WLAN
+	Expiration time.Time
+}
+
+type dpskBatchReq struct {
+	Amount             int    `json:"amount"`
+	UserName           string `json:"userName,omitempty"`
+	VlanID             int    `json:"vlanId,omitempty"`
+	ExpirationDateTime int64  `json:"expirationDateTime,omitempty"`
+}
+
+func dpskEp(zoneID, wlanID string) string {
+	return fmt.Sprintf("/rkszones/%s/wlans/%s/dpsk", zoneID, wlanID)
+}
+
+// GenerateDpsks generates a batch of Keys on a DPSK WLAN returning them
+func (c *Client) GenerateDpsks(zoneID, wlanID string, b DpskBatch) ([]RksDpsk, error) {
+	if b.Count < 1 {
+		return nil, fmt.Errorf("dpsk batch count must be at least 1")
+	}
+	req := dpskBatchReq{Amount: b.Count, UserName: b.UserNamePrefix, VlanID: b.VlanID}
+	if !b.Expiration.IsZero() {
+		req.ExpirationDateTime = b.Expiration.UnixNano() / int64(time.Millisecond)
+	}
+	var res struct {
+		List []RksDpsk `json:"list"`
+	}
+	err := c.send("POST", dpskEp(zoneID, wlanID)+"/batchGenDpsks", RksOptions{}, req, &res)
+	return res.List, err
+}
+
+// ListDpsks retrieves the Keys of a DPSK WLAN
+func (c *Client) ListDpsks(zoneID, wlanID string) ([]RksDpsk, error) {
+	var dpsks []RksDpsk
+	err := c.getList(dpskEp(zoneID, wlanID), RksOptions{}, &dpsks)
+	return dpsks, err
+}
+
+// DeleteDpsks removes Keys from a DPSK WLAN
+func (c *Client) DeleteDpsks(zoneID, wlanID string, ids ...string) error {
+	body := struct {
+		IDList []string `json:"idList"`
+	}{ids}
+	return c.send("DELETE", dpskEp(zoneID, wlanID), RksOptions{}, body, nil)
+}
+
+// UploadDpskCsv imports the Keys of a CSV file (in the format the Controller
+// exports) to a DPSK WLAN
+func (c *Client) UploadDpskCsv(zoneID, wlanID string, r io.Reader) error {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("file", "dpsk.csv")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return fmt.Errorf("failed to read dpsk csv: %v", err)
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+	ep := dpskEp(zoneID, wlanID) + "/upload_csv"
+	return c.sendBody("POST", ep, RksOptions{}, mw.FormDataContentType(), &buf, nil)
+}
+
+// DpskQRPayload the Wi-Fi QR Code payload joining ssid with passphrase
+// (ie WIFI:T:WPA;S:dorm;P:secret;;) as phone cameras read it
+func DpskQRPayload(ssid, passphrase string) string {
+	return fmt.Sprintf("WIFI:T:WPA;S:%s;P:%s;;", escapeQR(ssid), escapeQR(passphrase))
+}
+
+var qrEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `:`, `\:`, `"`, `\"`)
+
+func escapeQR(s string) string {
+	return qrEscaper.Replace(s)
+}
+
+// WriteDpskCsv writes dpsks as CSV for printing: the User Name, Passphrase,
+// VLAN, Expiration (RFC 3339, empty when never) and QR Code payload for ssid
+func WriteDpskCsv(w io.Writer, ssid string, dpsks []RksDpsk) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"userName", "passphrase", "vlanId", "expiration", "qrPayload"})
+	for _, d := range dpsks {
+		var vlan, expires string
+		if d.VlanID != 0 {
+			vlan = strconv.Itoa(d.VlanID)
+		}
+		if t := d.Expires(); !t.IsZero() {
+			expires = t.UTC().Format(time.RFC3339)
+		}
+		cw.Write([]string{d.UserName, d.Passphrase, vlan, expires, DpskQRPayload(ssid, d.Passphrase)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
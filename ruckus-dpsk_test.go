@@ -0,0 +1,82 @@
+package ruckus
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDpskQRPayload(t *testing.T) {
+	tests := []struct {
+		ssid, passphrase string
+		want             string
+	}{
+		{"dorm", "secret", `WIFI:T:WPA;S:dorm;P:secret;;`},
+		{"a;b", "c;d", `WIFI:T:WPA;S:a\;b;P:c\;d;;`},
+		{"a:b", "c,d", `WIFI:T:WPA;S:a\:b;P:c\,d;;`},
+		{`a\b`, `"quoted"`, `WIFI:T:WPA;S:a\\b;P:\"quoted\";;`},
+		// a backslash is escaped as itself, not as the start of an escape
+		{`\;`, `;\`, `WIFI:T:WPA;S:\\\;;P:\;\\;;`},
+	}
+	for _, tt := range tests {
+		if got := DpskQRPayload(tt.ssid, tt.passphrase); got != tt.want {
+			t.Errorf("DpskQRPayload(%q, %q) = %s; want %s", tt.ssid, tt.passphrase, got, tt.want)
+		}
+	}
+}
+
+func TestWriteDpskCsv(t *testing.T) {
+	expires := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	dpsks := []RksDpsk{
+		{UserName: "room-101", Passphrase: "p,1", VlanID: 101, ExpirationDateTime: expires.UnixNano() / int64(time.Millisecond)},
+		{UserName: "room-102", Passphrase: "p;2"},
+	}
+	var buf bytes.Buffer
+	if err := WriteDpskCsv(&buf, "dorm", dpsks); err != nil {
+		t.Fatalf("WriteDpskCsv() = %v", err)
+	}
+	want := `userName,passphrase,vlanId,expiration,qrPayload
+room-101,"p,1",101,2026-09-01T12:00:00Z,"WIFI:T:WPA;S:dorm;P:p\,1;;"
+room-102,p;2,,,WIFI:T:WPA;S:dorm;P:p\;2;;
+`
+	if buf.String() != want {
+		t.Errorf("WriteDpskCsv() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestUploadDpskCsv(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("POST /rkszones/z1/wlans/w1/dpsk/upload_csv", `{}`)
+	csv := "userName,passphrase\nroom-101,secret\n"
+
+	if err := c.UploadDpskCsv("z1", "w1", strings.NewReader(csv)); err != nil {
+		t.Fatalf("UploadDpskCsv() = %v", err)
+	}
+	reqs := f.received("POST /rkszones/z1/wlans/w1/dpsk/upload_csv")
+	if len(reqs) != 1 {
+		t.Fatalf("received %d uploads; want 1", len(reqs))
+	}
+	// the body opens with the boundary of the form
+	boundary := strings.TrimPrefix(strings.SplitN(reqs[0].Body, "\r\n", 2)[0], "--")
+	part, err := multipart.NewReader(strings.NewReader(reqs[0].Body), boundary).NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := ioutil.ReadAll(part)
+	if part.FormName() != "file" || part.FileName() != "dpsk.csv" || string(got) != csv {
+		t.Errorf("uploaded %s %s %q; want file dpsk.csv %q", part.FormName(), part.FileName(), got, csv)
+	}
+}
+
+func TestUploadDpskCsvRejected(t *testing.T) {
+	f, c := newFakeController(t)
+	f.onStatus("POST /rkszones/z1/wlans/w1/dpsk/upload_csv", 400, `{"message":"invalid csv"}`)
+
+	err := c.UploadDpskCsv("z1", "w1", strings.NewReader("garbage"))
+	if rerr, ok := err.(*RksError); !ok || rerr.StatusCode != 400 || rerr.Message != "invalid csv" {
+		t.Errorf("UploadDpskCsv() = %v; want the RksError of the controller", err)
+	}
+}
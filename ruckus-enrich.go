@@ -0,0 +1,173 @@
+package ruckus
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// EnrichOptions controls which AP Details EnrichAps retrieves
+// and how hard it may press the Controller while doing so
+type EnrichOptions struct {
+	// Retrieve the LAN Interface (GetApIntf)
+	Interfaces bool
+	// Retrieve the LLDP Neighbor (GetApLldp)
+	Lldp bool
+	// Retrieve the Operational Summary (GetApOperational)
+	Operational bool
+	// optional: the number of workers issuing requests.
+	// Default 8
+	Concurrency int
+	// optional: the max number of requests per second across ALL workers.
+	// this is in addition to the Client Limiter.
+	// Default 0 (unlimited)
+	RatePerSecond float64
+}
+
+// EnrichedAp an AP along with the Details retrieved by EnrichAps
+type EnrichedAp struct {
+	RksAp
+	Intf        ApIntf
+	Lldp        ApLldp
+	Operational ApOperational
+	// Errs maps the Detail (interfaces|lldp|operational)
+	// that could not be retrieved to the reason it failed
+	Errs map[string]error
+}
+
+// enrichedApDetails the fields an EnrichedAp adds to its RksAp
+type enrichedApDetails struct {
+	Intf        ApIntf
+	Lldp        ApLldp
+	Operational ApOperational
+}
+
+// MarshalJSON encodes the AP (see RksAp.MarshalJSON) along with its Details
+// which the methods of the embedded RksAp would otherwise leave out
+func (e EnrichedAp) MarshalJSON() ([]byte, error) {
+	ap, err := json.Marshal(e.RksAp)
+	if err != nil {
+		return nil, err
+	}
+	details, err := json.Marshal(struct {
+		enrichedApDetails
+		Errs map[string]error
+	}{enrichedApDetails{e.Intf, e.Lldp, e.Operational}, e.Errs})
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(ap, &fields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(details, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes the AP and its Details; Errs is not decoded
+// (an error has no JSON form)
+func (e *EnrichedAp) UnmarshalJSON(data []byte) error {
+	var d enrichedApDetails
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &e.RksAp); err != nil {
+		return err
+	}
+	e.Intf, e.Lldp, e.Operational = d.Intf, d.Lldp, d.Operational
+	return nil
+}
+
+// Failed reports whether any of the requested Details could not be retrieved
+func (e EnrichedAp) Failed() bool {
+	return len(e.Errs) > 0
+}
+
+const defaultEnrichConcurrency = 8
+
+// EnrichAps retrieves the per-AP Details of aps through a bounded pool of workers
+// The result is keyed by AP MAC Address; a failure retrieving one Detail is
+// recorded on that AP and does not stop the others. If ctx is cancelled the
+// APs enriched so far are returned along with the ctx error
+func (c *Client) EnrichAps(ctx context.Context, aps []RksAp, o EnrichOptions) (map[string]EnrichedAp, error) {
+	workers := o.Concurrency
+	if workers <= 0 {
+		workers = defaultEnrichConcurrency
+	}
+	if workers > len(aps) {
+		workers = len(aps)
+	}
+	// wait blocks until the next request may be issued
+	wait := func() error { return ctx.Err() }
+	if o.RatePerSecond > 0 {
+		lim := NewRateLimiter(o.RatePerSecond, 1)
+		wait = func() error { return lim.Wait(ctx) }
+	}
+
+	jobs := make(chan RksAp)
+	results := make(chan EnrichedAp)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ap := range jobs {
+				results <- c.enrichAp(ap, o, wait)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, ap := range aps {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- ap:
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	enriched := make(map[string]EnrichedAp, len(aps))
+	for e := range results {
+		enriched[e.MacAddr] = e
+	}
+	return enriched, ctx.Err()
+}
+
+func (c *Client) enrichAp(ap RksAp, o EnrichOptions, wait func() error) EnrichedAp {
+	e := EnrichedAp{RksAp: ap, Errs: make(map[string]error)}
+	if o.Interfaces {
+		err := wait()
+		if err == nil {
+			e.Intf, err = c.GetApIntf(ap.MacAddr)
+		}
+		if err != nil {
+			e.Errs["interfaces"] = err
+		}
+	}
+	if o.Lldp {
+		err := wait()
+		if err == nil {
+			e.Lldp, err = c.GetApLldp(ap.MacAddr)
+		}
+		if err != nil {
+			e.Errs["lldp"] = err
+		}
+	}
+	if o.Operational {
+		err := wait()
+		if err == nil {
+			e.Operational, err = c.GetApOperational(ap.MacAddr)
+		}
+		if err != nil {
+			e.Errs["operational"] = err
+		}
+	}
+	return e
+}
@@ -0,0 +1,138 @@
+package ruckus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// inFlight a Transport recording the max number of requests in flight at once
+type inFlight struct {
+	next     http.RoundTripper
+	mu       sync.Mutex
+	cur, max int
+}
+
+func (f *inFlight) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	f.cur++
+	if f.cur > f.max {
+		f.max = f.cur
+	}
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.cur--
+		f.mu.Unlock()
+	}()
+	// hold the request long enough for the other workers to start theirs
+	time.Sleep(2 * time.Millisecond)
+	return f.next.RoundTrip(req)
+}
+
+func TestEnrichAps(t *testing.T) {
+	f, c := newFakeController(t)
+	var aps []RksAp
+	for i := 0; i < 6; i++ {
+		mac := fmt.Sprintf("aa:00:%02d", i)
+		aps = append(aps, RksAp{MacAddr: mac})
+		f.on("GET /aps/"+mac+"/operational/summary", `{}`)
+		if i == 3 {
+			// one AP fails a single Detail
+			f.onStatus("GET /aps/"+mac+"/apLldpNeighbors", http.StatusInternalServerError, `{"message":"timeout"}`)
+			continue
+		}
+		f.on("GET /aps/"+mac+"/apLldpNeighbors", `{"list":[{"lldpSysName":"sw1","lldpPortID":"ifname Gi1/0/`+fmt.Sprint(i)+`"}]}`)
+	}
+	tr := &inFlight{next: c.http.Transport}
+	c.http.Transport = tr
+
+	enriched, err := c.EnrichAps(context.Background(), aps, EnrichOptions{Lldp: true, Operational: true, Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(enriched) != len(aps) {
+		t.Fatalf("enriched %d APs; want %d", len(enriched), len(aps))
+	}
+	for _, ap := range aps {
+		e := enriched[ap.MacAddr]
+		if ap.MacAddr == "aa:00:03" {
+			if _, ok := e.Errs["lldp"]; !ok || len(e.Errs) != 1 {
+				t.Errorf("%s errs = %v; want only lldp", ap.MacAddr, e.Errs)
+			}
+			continue
+		}
+		if e.Failed() || e.Lldp.RemoteHostname != "sw1" {
+			t.Errorf("%s = %+v", ap.MacAddr, e)
+		}
+	}
+	if n := len(f.received("")); n != 2*len(aps) {
+		t.Errorf("%d requests; want %d", n, 2*len(aps))
+	}
+	if tr.max > 2 {
+		t.Errorf("%d requests in flight; want at most 2", tr.max)
+	}
+}
+
+func TestEnrichApsCancelled(t *testing.T) {
+	_, c := newFakeController(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	aps := []RksAp{{MacAddr: "aa:00:01"}, {MacAddr: "aa:00:02"}}
+	enriched, err := c.EnrichAps(ctx, aps, EnrichOptions{Operational: true})
+	if err != context.Canceled {
+		t.Errorf("EnrichAps() = %v; want the context error", err)
+	}
+	for mac, e := range enriched {
+		if !e.Failed() {
+			t.Errorf("%s enriched after the context was cancelled", mac)
+		}
+	}
+}
+
+func TestEnrichApsNone(t *testing.T) {
+	_, c := newFakeController(t)
+	enriched, err := c.EnrichAps(context.Background(), nil, EnrichOptions{Operational: true})
+	if err != nil || len(enriched) != 0 {
+		t.Errorf("EnrichAps(nil) = %v, %v", enriched, err)
+	}
+}
+
+func TestEnrichedApJSON(t *testing.T) {
+	var ap RksAp
+	if err := json.Unmarshal([]byte(`{"apMac":"AA:BB","deviceName":"ap1","meshRole":"Root"}`), &ap); err != nil {
+		t.Fatal(err)
+	}
+	e := EnrichedAp{
+		RksAp: ap,
+		Lldp:  ApLldp{RemoteHostname: "sw1", RemoteIntf: "ge-0/0/1"},
+		Errs:  map[string]error{"interfaces": fmt.Errorf("unavailable")},
+	}
+	doc, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &got); err != nil {
+		t.Fatal(err)
+	}
+	// the Details are not lost to the methods of the embedded RksAp
+	for _, k := range []string{"apMac", "meshRole", "Intf", "Lldp", "Operational", "Errs"} {
+		if _, ok := got[k]; !ok {
+			t.Errorf("json.Marshal() lacks %s: %s", k, doc)
+		}
+	}
+
+	var back EnrichedAp
+	if err := json.Unmarshal(doc, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.MacAddr != "AA:BB" || back.Lldp != e.Lldp {
+		t.Errorf("json.Unmarshal() = %+v; want %+v", back, e)
+	}
+}
@@ -0,0 +1,200 @@
+package ruckus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RksApFirmware an AP Firmware available on the Controller
+type RksApFirmware struct {
+	FirmwareVersion string   `json:"firmwareVersion"`
+	SupportedModels []string `json:"supportedApModels"`
+}
+
+// RksZoneApFirmware the AP Firmware of a Zone and the Versions it may move to
+type RksZoneApFirmware struct {
+	FirmwareVersion   string          `json:"firmwareVersion"`
+	SupportedVersions []RksApFirmware `json:"supportedVersions"`
+}
+
+// ListApFirmwares retrieves the AP Firmwares available on the Controller
+func (c *Client) ListApFirmwares() ([]RksApFirmware, error) {
+	var fws []RksApFirmware
+	err := c.getList("/apFirmwares", RksOptions{}, &fws)
+	return fws, err
+}
+
+// GetZoneApFirmware retrieves the AP Firmware of a Zone along with the available Versions
+func (c *Client) GetZoneApFirmware(zoneID string) (RksZoneApFirmware, error) {
+	var fw RksZoneApFirmware
+	err := c.send("GET", fmt.Sprintf("/rkszones/%s/apFirmware", zoneID), RksOptions{}, nil, &fw)
+	return fw, err
+}
+
+// UpgradeZoneApFirmware moves the APs of a Zone to an AP Firmware Version
+// the APs download the Firmware and reboot; see StagedApUpgrade
+func (c *Client) UpgradeZoneApFirmware(zoneID, version string) error {
+	body := struct {
+		FirmwareVersion string `json:"firmwareVersion"`
+	}{version}
+	return c.send("PUT", fmt.Sprintf("/rkszones/%s/apFirmware", zoneID), RksOptions{}, body, nil)
+}
+
+// GetZoneAps retrieves the APs of a Zone
+func (c *Client) GetZoneAps(zoneID string) ([]RksAp, error) {
+	q := newQuery("apMac")
+	q.Filters = append(q.Filters, Mapper{Type: "ZONE", Value: zoneID})
+	var aps []RksAp
+	err := c.queryList("/query/ap", q, RksOptions{}, &aps)
+	return aps, err
+}
+
+// CompareFirmware compares two dotted Firmware Versions (ie 6.1.0.0.1234)
+// numerically returning -1, 0 or 1
+func CompareFirmware(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv string
+		if i < len(as) {
+			av = as[i]
+		}
+		if i < len(bs) {
+			bv = bs[i]
+		}
+		an, aerr := strconv.Atoi(av)
+		bn, berr := strconv.Atoi(bv)
+		if aerr != nil || berr != nil {
+			// a part that is not numeric is compared as text
+			if c := strings.Compare(av, bv); c != 0 {
+				return c
+			}
+			continue
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// ZoneFirmwareCompliance the APs of a Zone that lag behind the target Firmware
+type ZoneFirmwareCompliance struct {
+	ZoneID   string
+	ZoneName string
+	TotalAps int
+	// the number of APs per Firmware Version found in the Zone
+	Versions   map[string]int
+	LaggingAps []RksAp
+}
+
+// FirmwareReport the APs lagging behind a target Firmware across Zones
+type FirmwareReport struct {
+	Target     string
+	TotalAps   int
+	LaggingAps int
+	// Zones with at least one lagging AP sorted by Zone Name
+	Zones []ZoneFirmwareCompliance
+}
+
+// FirmwareComplianceReport cross-references the Firmware of aps against target
+func FirmwareComplianceReport(aps []RksAp, target string) FirmwareReport {
+	r := FirmwareReport{Target: target, TotalAps: len(aps)}
+	zones := make(map[string]*ZoneFirmwareCompliance)
+	for _, ap := range aps {
+		z, ok := zones[ap.ZoneID]
+		if !ok {
+			z = &ZoneFirmwareCompliance{
+				ZoneID:   ap.ZoneID,
+				ZoneName: ap.ZoneName,
+				Versions: make(map[string]int),
+			}
+			zones[ap.ZoneID] = z
+		}
+		z.TotalAps++
+		z.Versions[ap.Firmware]++
+		if CompareFirmware(ap.Firmware, target) < 0 {
+			z.LaggingAps = append(z.LaggingAps, ap)
+			r.LaggingAps++
+		}
+	}
+	for _, z := range zones {
+		if len(z.LaggingAps) > 0 {
+			r.Zones = append(r.Zones, *z)
+		}
+	}
+	sort.Slice(r.Zones, func(i, j int) bool { return r.Zones[i].ZoneName < r.Zones[j].ZoneName })
+	return r
+}
+
+// RolloutOptions controls StagedApUpgrade
+type RolloutOptions struct {
+	// how long to wait for the APs of a Zone to come back online.
+	// Default 30 minutes
+	ZoneTimeout time.Duration
+	// keep upgrading the next Zones when one does not come back in time.
+	// Default false (stop)
+	ContinueOnError bool
+}
+
+// ZoneRollout the outcome of upgrading one Zone
+type ZoneRollout struct {
+	ZoneID string
+	// the APs that were not online on the target Version when the Zone completed|timed out
+	Pending []RksAp
+	Err     error
+}
+
+// StagedApUpgrade upgrades Zones one at a time waiting for the APs of each
+// Zone to come back online on version before moving to the next one
+func (c *Client) StagedApUpgrade(ctx context.Context, zoneIDs []string, version string, o RolloutOptions) ([]ZoneRollout, error) {
+	if o.ZoneTimeout <= 0 {
+		o.ZoneTimeout = 30 * time.Minute
+	}
+	var results []ZoneRollout
+	for _, zoneID := range zoneIDs {
+		r := ZoneRollout{ZoneID: zoneID}
+		r.Err = c.UpgradeZoneApFirmware(zoneID, version)
+		if r.Err == nil {
+			r.Pending, r.Err = c.waitForZoneAps(ctx, zoneID, version, o.ZoneTimeout)
+		}
+		results = append(results, r)
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		if r.Err != nil && !o.ContinueOnError {
+			return results, fmt.Errorf("zone %s: %v", zoneID, r.Err)
+		}
+	}
+	return results, nil
+}
+
+// waitForZoneAps polls the APs of a Zone until all are Online on version
+func (c *Client) waitForZoneAps(ctx context.Context, zoneID, version string, timeout time.Duration) ([]RksAp, error) {
+	var pending []RksAp
+	err := poll(ctx, timeout, func() (bool, error) {
+		aps, err := c.GetZoneAps(zoneID)
+		if err != nil {
+			// the Controller may be busy serving the upgrade; try again
+			return false, nil
+		}
+		pending = pending[:0]
+		for _, ap := range aps {
+			if ap.Firmware != version || !strings.EqualFold(ap.Status, "online") {
+				pending = append(pending, ap)
+			}
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		return pending, fmt.Errorf("%d aps not online on %s: %v", len(pending), version, err)
+	}
+	return pending, nil
+}
@@ -0,0 +1,249 @@
+package ruckus
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	texttemplate "text/template"
+	"time"
+)
+
+// RksGuestPass a Guest Pass issued for a Guest Access WLAN
+type RksGuestPass struct {
+	ID        string `json:"userId"`
+	GuestName string `json:"guestName"`
+	Key       string `json:"key"`
+	WlanName  string `json:"wlan"`
+	ZoneName  string `json:"zoneName"`
+	// epoch milliseconds
+	CreateDateTime int64 `json:"createDateTime"`
+	Expiration     int64 `json:"expiration"`
+}
+
+// Expires the time the Pass expires
+func (p RksGuestPass) Expires() time.Time {
+	return msToTime(p.Expiration)
+}
+
+// GuestPassRequest describes the Guest Passes to generate
+type GuestPassRequest struct {
+	ZoneID    string
+	WlanID    string
+	GuestName string
+	// Default 1
+	Count int
+	// how long a Pass may be used after it is issued; whole hours
+	Validity time.Duration
+	// the Devices that may use a Pass at once; 0 is unlimited
+	MaxDevices int
+	// how long before the Guest must log in again, in whole minutes; 0 never
+	SessionDuration time.Duration
+}
+
+type rksExpiration struct {
+	Value int    `json:"expirationValue"`
+	Unit  string `json:"expirationUnit"`
+}
+
+type guestPassReq struct {
+	GuestName      string        `json:"guestName"`
+	Zone           RksObject     `json:"zone"`
+	Wlan           RksObject     `json:"wlan"`
+	NumberOfPasses int           `json:"numberOfPasses"`
+	PassValidFor   rksExpiration `json:"passValidFor"`
+	MaxDevices     struct {
+		MaxAllowed       string `json:"maxAllowed"`
+		MaxDevicesNumber int    `json:"maxDevicesNumber,omitempty"`
+	} `json:"maxDevices"`
+	SessionDuration struct {
+		RequireGuestRelogin bool   `json:"requireGuestRelogin"`
+		Duration            int    `json:"duration,omitempty"`
+		Unit                string `json:"unit,omitempty"`
+	} `json:"sessionDuration"`
+}
+
+// durationUnit expresses d in the largest of DAY|HOUR|MINUTE dividing it
+// d must be a whole number of min: the Controller cannot express the rest
+func durationUnit(d, min time.Duration) (int, string, error) {
+	if d%min != 0 {
+		return 0, "", fmt.Errorf("%v is not a whole number of %v", d, min)
+	}
+	switch {
+	case d%(24*time.Hour) == 0:
+		return int(d / (24 * time.Hour)), "DAY", nil
+	case d%time.Hour == 0:
+		return int(d / time.Hour), "HOUR", nil
+	}
+	return int(d / time.Minute), "MINUTE", nil
+}
+
+// GenerateGuestPasses issues Guest Passes for a Guest Access WLAN returning them
+func (c *Client) GenerateGuestPasses(r GuestPassRequest) ([]RksGuestPass, error) {
+	if r.Validity <= 0 {
+		return nil, fmt.Errorf("guest pass validity must be positive")
+	}
+	req := guestPassReq{
+		GuestName:      r.GuestName,
+		Zone:           RksObject{ID: r.ZoneID},
+		Wlan:           RksObject{ID: r.WlanID},
+		NumberOfPasses: r.Count,
+	}
+	if req.NumberOfPasses < 1 {
+		req.NumberOfPasses = 1
+	}
+	var err error
+	req.PassValidFor.Value, req.PassValidFor.Unit, err = durationUnit(r.Validity, time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("invalid guest pass validity: %v", err)
+	}
+	req.MaxDevices.MaxAllowed = "UNLIMITED"
+	if r.MaxDevices > 0 {
+		req.MaxDevices.MaxAllowed = "LIMITED"
+		req.MaxDevices.MaxDevicesNumber = r.MaxDevices
+	}
+	if r.SessionDuration > 0 {
+		req.SessionDuration.RequireGuestRelogin = true
+		req.SessionDuration.Duration, req.SessionDuration.Unit, err = durationUnit(r.SessionDuration, time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("invalid guest pass session duration: %v", err)
+		}
+	}
+	var res struct {
+		List []RksGuestPass `json:"list"`
+	}
+	err = c.send("POST", "/identity/guestpass/generate", RksOptions{}, req, &res)
+	return res.List, err
+}
+
+// ListGuestPasses retrieves the Guest Passes issued on the Controller
+func (c *Client) ListGuestPasses(o RksOptions) ([]RksGuestPass, error) {
+	var passes []RksGuestPass
+	err := c.queryList("/identity/guestpass/list", newQuery("guestName"), o, &passes)
+	return passes, err
+}
+
+// DeleteGuestPass revokes a Guest Pass
+func (c *Client) DeleteGuestPass(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/identity/guestpass/%s", id), RksOptions{}, nil, nil)
+}
+
+// RksGuestAccess properties of a Guest Access Service (Portal) of a Zone
+type RksGuestAccess struct {
+	ID          string             `json:"id,omitempty"`
+	ZoneID      string             `json:"zoneId,omitempty"`
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	UserSession *RksPortalSession  `json:"userSession,omitempty"`
+	Redirect    *RksPortalRedirect `json:"redirect,omitempty"`
+}
+
+// RksPortalSession the Session Timers of a Portal
+type RksPortalSession struct {
+	TimeoutInMin     int `json:"timeoutInMin,omitempty"`
+	GracePeriodInMin int `json:"gracePeriodInMin,omitempty"`
+}
+
+// RksPortalRedirect where a Portal sends Clients once authenticated
+// an empty URL keeps the page they asked for
+type RksPortalRedirect struct {
+	URL string `json:"url,omitempty"`
+}
+
+// GetGuestAccesses retrieves the Guest Access Services of a Zone
+func (c *Client) GetGuestAccesses(zoneID string) ([]RksGuestAccess, error) {
+	var svcs []RksGuestAccess
+	err := c.getList(fmt.Sprintf("/rkszones/%s/portals/guest", zoneID), RksOptions{}, &svcs)
+	return svcs, err
+}
+
+// GetGuestAccess retrieves a Guest Access Service of a Zone
+func (c *Client) GetGuestAccess(zoneID, id string) (RksGuestAccess, error) {
+	var svc RksGuestAccess
+	ep := fmt.Sprintf("/rkszones/%s/portals/guest/%s", zoneID, id)
+	err := c.send("GET", ep, RksOptions{}, nil, &svc)
+	return svc, err
+}
+
+// CreateGuestAccess creates a Guest Access Service in a Zone returning its ID
+func (c *Client) CreateGuestAccess(zoneID string, g RksGuestAccess) (string, error) {
+	var created RksObject
+	ep := fmt.Sprintf("/rkszones/%s/portals/guest", zoneID)
+	err := c.send("POST", ep, RksOptions{}, g, &created)
+	return created.ID, err
+}
+
+// UpdateGuestAccess modifies the fields of a Guest Access Service set in g
+func (c *Client) UpdateGuestAccess(zoneID, id string, g RksGuestAccess) error {
+	ep := fmt.Sprintf("/rkszones/%s/portals/guest/%s", zoneID, id)
+	return c.update(ep, g)
+}
+
+// PatchGuestAccess PATCHes the fields of a Guest Access Service that differ
+// from original, the Service as retrieved (see Patch)
+func (c *Client) PatchGuestAccess(zoneID string, original, modified RksGuestAccess) error {
+	ep := fmt.Sprintf("/rkszones/%s/portals/guest/%s", zoneID, original.ID)
+	return c.patch(ep, original, modified)
+}
+
+// DeleteGuestAccess removes a Guest Access Service from a Zone
+func (c *Client) DeleteGuestAccess(zoneID, id string) error {
+	ep := fmt.Sprintf("/rkszones/%s/portals/guest/%s", zoneID, id)
+	return c.send("DELETE", ep, RksOptions{}, nil, nil)
+}
+
+// GuestPassSheet the data a Guest Pass print Template is executed with
+type GuestPassSheet struct {
+	// the SSID Guests join
+	SSID   string
+	Passes []RksGuestPass
+}
+
+// GuestPassTemplate a text|html Template rendering a GuestPassSheet
+type GuestPassTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+var guestPassFuncs = map[string]interface{}{
+	"expires": func(p RksGuestPass) string {
+		return p.Expires().Format("2006-01-02 15:04")
+	},
+}
+
+// GuestPassText the default plain text Guest Pass print Template
+var GuestPassText = texttemplate.Must(texttemplate.New("guestpass").Funcs(guestPassFuncs).Parse(
+	`{{range .Passes}}------------------------------
+Guest:    {{.GuestName}}
+Network:  {{$.SSID}}
+Pass:     {{.Key}}
+Expires:  {{expires .}}
+{{end}}------------------------------
+`))
+
+// GuestPassHTML the default HTML Guest Pass print Template (a card per Pass)
+var GuestPassHTML = htmltemplate.Must(htmltemplate.New("guestpass").Funcs(guestPassFuncs).Parse(
+	`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Guest Passes</title>
+<style>
+.pass { border: 1px dashed #999; padding: 1em; margin: 1em; width: 20em; display: inline-block; page-break-inside: avoid; }
+.key { font-family: monospace; font-size: 1.5em; }
+</style>
+</head>
+<body>
+{{range .Passes}}<div class="pass">
+<p>Welcome {{.GuestName}}</p>
+<p>Network: <b>{{$.SSID}}</b></p>
+<p>Guest Pass: <span class="key">{{.Key}}</span></p>
+<p>Valid until {{expires .}}</p>
+</div>
+{{end}}</body>
+</html>
+`))
+
+// PrintGuestPasses renders passes for ssid to w using t
+// (GuestPassText, GuestPassHTML or a Template of your own)
+func PrintGuestPasses(w io.Writer, t GuestPassTemplate, ssid string, passes []RksGuestPass) error {
+	return t.Execute(w, GuestPassSheet{SSID: ssid, Passes: passes})
+}
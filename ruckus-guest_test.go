@@ -0,0 +1,123 @@
+package ruckus
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateGuestPasses(t *testing.T) {
+	tests := []struct {
+		name string
+		r    GuestPassRequest
+		// the passValidFor, maxDevices and sessionDuration sent
+		want string
+	}{
+		{
+			name: "days unlimited",
+			r:    GuestPassRequest{Validity: 48 * time.Hour},
+			want: `{"expirationValue":2,"expirationUnit":"DAY"} {"maxAllowed":"UNLIMITED"} {"requireGuestRelogin":false}`,
+		},
+		{
+			name: "hours limited relogin",
+			r:    GuestPassRequest{Validity: 36 * time.Hour, MaxDevices: 2, SessionDuration: 90 * time.Minute},
+			want: `{"expirationValue":36,"expirationUnit":"HOUR"} {"maxAllowed":"LIMITED","maxDevicesNumber":2} {"requireGuestRelogin":true,"duration":90,"unit":"MINUTE"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeController(t)
+			f.on("POST /identity/guestpass/generate", `{"list":[{"userId":"u1","guestName":"visitor","key":"ABCDE"}]}`)
+			tt.r.ZoneID, tt.r.WlanID, tt.r.GuestName = "z1", "w1", "visitor"
+
+			passes, err := c.GenerateGuestPasses(tt.r)
+			if err != nil {
+				t.Fatalf("GenerateGuestPasses() = %v", err)
+			}
+			if len(passes) != 1 || passes[0].Key != "ABCDE" {
+				t.Errorf("GenerateGuestPasses() = %+v", passes)
+			}
+			reqs := f.received("POST /identity/guestpass/generate")
+			var sent map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(reqs[0].Body), &sent); err != nil {
+				t.Fatal(err)
+			}
+			var zone, wlan RksObject
+			json.Unmarshal(sent["zone"], &zone)
+			json.Unmarshal(sent["wlan"], &wlan)
+			if string(sent["numberOfPasses"]) != "1" || zone.ID != "z1" || wlan.ID != "w1" {
+				t.Errorf("sent %s", reqs[0].Body)
+			}
+			got := string(sent["passValidFor"]) + " " + string(sent["maxDevices"]) + " " + string(sent["sessionDuration"])
+			if got != tt.want {
+				t.Errorf("sent %s; want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateGuestPassesInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		r    GuestPassRequest
+		want string
+	}{
+		{"no validity", GuestPassRequest{}, "must be positive"},
+		{"part of an hour", GuestPassRequest{Validity: 90 * time.Minute}, "validity: 1h30m0s is not a whole number of 1h0m0s"},
+		{"part of a minute", GuestPassRequest{Validity: time.Hour, SessionDuration: 90 * time.Second}, "session duration: 1m30s is not a whole number of 1m0s"},
+	}
+	for _, tt := range tests {
+		f, c := newFakeController(t)
+		_, err := c.GenerateGuestPasses(tt.r)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: GenerateGuestPasses() = %v; want %q", tt.name, err, tt.want)
+		}
+		if reqs := f.received(""); len(reqs) != 0 {
+			t.Errorf("%s: sent %d requests; want none", tt.name, len(reqs))
+		}
+	}
+}
+
+func TestPrintGuestPasses(t *testing.T) {
+	expires := time.Date(2026, 9, 1, 18, 30, 0, 0, time.Local)
+	passes := []RksGuestPass{
+		{GuestName: "Ann <b>", Key: "ABCDE", Expiration: expires.UnixNano() / int64(time.Millisecond)},
+		{GuestName: "Bob", Key: "FGHIJ", Expiration: expires.UnixNano() / int64(time.Millisecond)},
+	}
+
+	var text bytes.Buffer
+	if err := PrintGuestPasses(&text, GuestPassText, "guest-wifi", passes); err != nil {
+		t.Fatalf("PrintGuestPasses(text) = %v", err)
+	}
+	want := `------------------------------
+Guest:    Ann <b>
+Network:  guest-wifi
+Pass:     ABCDE
+Expires:  2026-09-01 18:30
+------------------------------
+Guest:    Bob
+Network:  guest-wifi
+Pass:     FGHIJ
+Expires:  2026-09-01 18:30
+------------------------------
+`
+	if text.String() != want {
+		t.Errorf("text =\n%s\nwant\n%s", text.String(), want)
+	}
+
+	var html bytes.Buffer
+	if err := PrintGuestPasses(&html, GuestPassHTML, "guest-wifi", passes); err != nil {
+		t.Fatalf("PrintGuestPasses(html) = %v", err)
+	}
+	out := html.String()
+	if n := strings.Count(out, `<div class="pass">`); n != 2 {
+		t.Errorf("html has %d cards; want 2", n)
+	}
+	for _, s := range []string{"Welcome Ann &lt;b&gt;", "<b>guest-wifi</b>", `<span class="key">FGHIJ</span>`, "Valid until 2026-09-01 18:30"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("html does not contain %q:\n%s", s, out)
+		}
+	}
+}
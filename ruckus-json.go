@@ -0,0 +1,273 @@
+package ruckus
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// knownFields caches the JSON names of the fields of a struct type
+var knownFields sync.Map
+
+// jsonFieldNames the JSON names of the (exported) fields of struct type t
+// including those of embedded structs
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if names, ok := knownFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for n := range jsonFieldNames(f.Type) {
+				names[n] = true
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	knownFields.Store(t, names)
+	return names
+}
+
+// unknownFields the fields of the JSON object data the struct type t does not model
+func unknownFields(data []byte, t reflect.Type) map[string]json.RawMessage {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		// not an object (ie null); there is nothing unknown
+		return nil
+	}
+	known := jsonFieldNames(t)
+	var unknown map[string]json.RawMessage
+	for k, raw := range all {
+		if known[k] || caseFoldKnown(k, known) {
+			continue
+		}
+		if unknown == nil {
+			unknown = make(map[string]json.RawMessage)
+		}
+		unknown[k] = raw
+	}
+	return unknown
+}
+
+// caseFoldKnown reports whether k matches a known field ignoring case
+// as encoding/json does when decoding
+func caseFoldKnown(k string, known map[string]bool) bool {
+	for n := range known {
+		if strings.EqualFold(n, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// rawDoc keeps the JSON document a resource was decoded from
+// so the fields a release adds survive a GET-modify-Save
+type rawDoc struct {
+	raw json.RawMessage
+}
+
+// Raw the JSON document the value was decoded from (nil when built in code)
+func (d rawDoc) Raw() json.RawMessage {
+	return d.raw
+}
+
+func (d *rawDoc) keep(data []byte) {
+	d.raw = append(json.RawMessage(nil), data...)
+}
+
+// RawHolder a resource that keeps the JSON document it was decoded from
+type RawHolder interface {
+	Raw() json.RawMessage
+}
+
+// MergeRaw encodes v and merges the result into the document v was decoded
+// from: the fields v models take their (possibly modified) values while
+// every other field, at any depth, is kept as retrieved. Objects of lists
+// identified by id|apMac|mac are merged by ID; other lists are replaced.
+// A zero value v holds for a field the document does not have is left out,
+// a nil one it does have is sent as null and an unmodified v gives back the
+// document as retrieved
+func MergeRaw(v RawHolder) (json.RawMessage, error) {
+	typed, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return mergeRaw(v.Raw(), typed)
+}
+
+// mergeRaw merges typed, the encoding of a resource, into raw (see MergeRaw)
+func mergeRaw(raw json.RawMessage, typed []byte) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return typed, nil
+	}
+	rv, err := toJSONValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	tv, err := toJSONValue(json.RawMessage(typed))
+	if err != nil {
+		return nil, err
+	}
+	merged := mergeValues(rv, tv)
+	if reflect.DeepEqual(merged, rv) {
+		return raw, nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(merged); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func mergeValues(raw, typed interface{}) interface{} {
+	switch tv := typed.(type) {
+	case map[string]interface{}:
+		rv, ok := raw.(map[string]interface{})
+		if !ok {
+			return typed
+		}
+		out := make(map[string]interface{}, len(rv)+len(tv))
+		for k, v := range rv {
+			out[k] = v
+		}
+		for k, v := range tv {
+			rk, ok := rv[k]
+			if !ok && isZeroJSON(v) {
+				// a field the Controller did not send; leave it unset
+				continue
+			}
+			if ok && v != nil && isZeroJSON(v) && !sameKind(rk, v) {
+				// a value of a type the field does not decode; keep it as sent
+				// (an explicit null clears the field whatever it held)
+				continue
+			}
+			out[k] = mergeValues(rk, v)
+		}
+		return out
+	case json.Number:
+		// keep the form the Controller sent the number in (ie 1.50 or "37.4")
+		if sameNumber(raw, tv) {
+			return raw
+		}
+		return typed
+	case []interface{}:
+		rv, ok := raw.([]interface{})
+		if !ok || !keyedList(rv) || !keyedList(tv) {
+			return typed
+		}
+		byKey := make(map[string]interface{}, len(rv))
+		for _, v := range rv {
+			k, _ := elementKey(v)
+			byKey[k] = v
+		}
+		out := make([]interface{}, len(tv))
+		for i, v := range tv {
+			k, _ := elementKey(v)
+			out[i] = mergeValues(byKey[k], v)
+		}
+		return out
+	}
+	return typed
+}
+
+// withUnknown sets the top level fields of doc a type does not model to
+// unknown; retrieved holds those doc had when decoded so that the fields
+// removed from unknown are dropped. doc is returned as is when they match
+func withUnknown(doc []byte, known map[string]bool, retrieved, unknown map[string]json.RawMessage) ([]byte, error) {
+	if reflect.DeepEqual(retrieved, unknown) || len(retrieved)+len(unknown) == 0 {
+		return doc, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, err
+	}
+	for k := range retrieved {
+		if _, ok := unknown[k]; !ok {
+			delete(fields, k)
+		}
+	}
+	for k, v := range unknown {
+		// the fields the type models take their value from it
+		if !known[k] && !caseFoldKnown(k, known) {
+			fields[k] = v
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(fields); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// sameNumber reports whether raw holds the number n, as a number or a string
+// (an empty string being a number the Controller left unset)
+func sameNumber(raw interface{}, n json.Number) bool {
+	var s string
+	switch r := raw.(type) {
+	case json.Number:
+		s = string(r)
+	case string:
+		s = strings.TrimSpace(r)
+		if s == "" {
+			s = "0"
+		}
+	default:
+		return false
+	}
+	rf, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return false
+	}
+	nf, err := n.Float64()
+	return err == nil && rf == nf
+}
+
+// sameKind reports whether a and b are the same kind of JSON value
+func sameKind(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return reflect.TypeOf(a) == reflect.TypeOf(b)
+}
+
+// isZeroJSON reports whether v is the JSON of a Go zero value
+// (an object when all its fields are)
+func isZeroJSON(v interface{}) bool {
+	switch t := v.(type) {
+	case string:
+		return t == ""
+	case bool:
+		return !t
+	case json.Number:
+		f, err := t.Float64()
+		return err == nil && f == 0
+	case map[string]interface{}:
+		for _, fv := range t {
+			if !isZeroJSON(fv) {
+				return false
+			}
+		}
+		return true
+	}
+	return isEmptyJSON(v)
+}
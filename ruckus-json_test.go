@@ -0,0 +1,115 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRawPreserved(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		// decodes doc into a new value, modifies it and returns it
+		edit func([]byte) (RawHolder, error)
+		want string
+	}{
+		{
+			name: "ap",
+			doc:  `{"apMac":"AA:BB","deviceName":"ap1","meshRole":"Root","lbsStatus":{"enabled":true}}`,
+			edit: func(d []byte) (RawHolder, error) {
+				var v RksAp
+				err := json.Unmarshal(d, &v)
+				v.ApName = "lobby-1"
+				return v, err
+			},
+			want: `{"apMac":"AA:BB","deviceName":"lobby-1","lbsStatus":{"enabled":true},"meshRole":"Root"}`,
+		},
+		{
+			name: "controller",
+			doc:  `{"id":"c1","name":"sz","fipsEnabled":false,"dataPlanes":[{"id":"dp1"}]}`,
+			edit: func(d []byte) (RawHolder, error) {
+				var v RksController
+				err := json.Unmarshal(d, &v)
+				v.Description = "primary"
+				return v, err
+			},
+			want: `{"dataPlanes":[{"id":"dp1"}],"description":"primary","fipsEnabled":false,"id":"c1","name":"sz"}`,
+		},
+		{
+			name: "ap group",
+			doc:  `{"id":"g1","name":"lobby","wifi24":{"txPower":"Full","channelWidth":20},"apModelSpecificConfigs":[]}`,
+			edit: func(d []byte) (RawHolder, error) {
+				var v RksApGroup
+				err := json.Unmarshal(d, &v)
+				v.Description = "entrance"
+				return v, err
+			},
+			want: `{"apModelSpecificConfigs":[],"description":"entrance","id":"g1","name":"lobby","wifi24":{"channelWidth":20,"txPower":"Full"}}`,
+		},
+		{
+			name: "ap config",
+			doc:  `{"mac":"AA:BB","name":"ap1","gpsSource":"GPS","login":{"apLoginName":"admin"}}`,
+			edit: func(d []byte) (RawHolder, error) {
+				var v RksApConfig
+				err := json.Unmarshal(d, &v)
+				v.Location = "lobby"
+				return v, err
+			},
+			want: `{"gpsSource":"GPS","location":"lobby","login":{"apLoginName":"admin"},"mac":"AA:BB","name":"ap1"}`,
+		},
+		{
+			name: "wlan",
+			doc:  `{"id":"w1","name":"staff","ssid":"staff","radiusOptions":{"nasIdType":"BSSID"}}`,
+			edit: func(d []byte) (RawHolder, error) {
+				var v RksWlanConfig
+				err := json.Unmarshal(d, &v)
+				v.SSID = "staff-5g"
+				return v, err
+			},
+			want: `{"id":"w1","name":"staff","radiusOptions":{"nasIdType":"BSSID"},"ssid":"staff-5g"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.edit([]byte(tt.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(v.Raw()) != tt.doc {
+				t.Errorf("Raw() = %s; want %s", v.Raw(), tt.doc)
+			}
+			// json.Marshal writes what MergeRaw does, ie in snapshots
+			got, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s; want %s", got, tt.want)
+			}
+			merged, err := MergeRaw(v)
+			if err != nil || string(merged) != tt.want {
+				t.Errorf("MergeRaw() = %s, %v; want %s", merged, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawUnmodified(t *testing.T) {
+	doc := `{"apMac":"AA:BB","deviceName":"ap1","numClients":3,"meshRole":"Root"}`
+	var ap RksAp
+	if err := json.Unmarshal([]byte(doc), &ap); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := json.Marshal(ap); err != nil || string(got) != doc {
+		t.Errorf("json.Marshal() = %s, %v; want the document as retrieved", got, err)
+	}
+	// built in code there is no document to merge into
+	got, err := json.Marshal(RksApGroup{Name: "new"})
+	if err != nil || string(got) != `{"name":"new"}` {
+		t.Errorf("json.Marshal() = %s, %v", got, err)
+	}
+	if strings.Contains(string(got), "raw") {
+		t.Errorf("json.Marshal() encoded the document: %s", got)
+	}
+}
@@ -0,0 +1,184 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Duplex the Duplex Mode of a Link
+type Duplex string
+
+// Duplex Modes
+const (
+	DuplexUnknown Duplex = ""
+	DuplexFull    Duplex = "full"
+	DuplexHalf    Duplex = "half"
+)
+
+// ApLanPort the Status and Configuration of an AP LAN Port
+type ApLanPort struct {
+	MacAddr string
+	// the Port Name (LAN1|LAN2...) and the AP Interface behind it (eth0|eth1...)
+	Name    string
+	Intf    string
+	Enabled bool
+	// the Link as reported (ie "Up 1000Mbps full") and its parsed form
+	PhyLink     string
+	PhyLinkUp   bool
+	SpeedMbps   int
+	Duplex      Duplex
+	LogicLink   string
+	LogicLinkUp bool
+	// the PoE Standard the AP is Powered with on this Port (if any)
+	PoEIn string
+	// whether the Port supplies PoE to a downstream Device
+	PoEOut       bool
+	VlanUntagID  int
+	VlanMembers  string
+	Profile      string
+	Dot1x        string
+	Dot1xEnabled bool
+}
+
+type apLanPortRES struct {
+	MacAddr     string `json:"apMac"`
+	Port        string `json:"port"`
+	Intf        string `json:"interface"`
+	Enabled     *bool  `json:"enabled"`
+	PhyLink     string `json:"phyLink"`
+	LogicLink   string `json:"logicLink"`
+	PoEIn       string `json:"poeStatus"`
+	PoEOut      bool   `json:"poeOutEnabled"`
+	VlanUntagID int    `json:"vlanUntagId"`
+	VlanMembers string `json:"members"`
+	Profile     string `json:"ethPortProfileName"`
+	Dot1x       string `json:"dot1x"`
+}
+
+// LanPortsUnavailableError the Controller did not report the LAN Ports of an AP
+// (an unsuccessful or unreadable response)
+type LanPortsUnavailableError struct {
+	MacAddr string
+	// the reason the response could not be read (nil when unsuccessful)
+	Err error
+}
+
+func (e *LanPortsUnavailableError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("failed to get lan ports of %s: %v", e.MacAddr, e.Err)
+	}
+	return fmt.Sprintf("failed to get lan ports of %s", e.MacAddr)
+}
+
+// GetApLanPorts retrieves every LAN Port of an AP. Unlike GetApIntf it
+// reports a *LanPortsUnavailableError when the Controller does not report them
+func (c *Client) GetApLanPorts(macAddr string) ([]ApLanPort, error) {
+	uri := fmt.Sprintf("https://%s:8443/wsg/api/scg/aps/%s", c.host, macAddr)
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	c.addQS(req, RksOptions{})
+	res, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resp: %v", err)
+	}
+	defer res.Body.Close()
+	var apResp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			LanPorts []apLanPortRES `json:"lanPortStatus"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&apResp); err != nil {
+		return nil, &LanPortsUnavailableError{MacAddr: macAddr, Err: err}
+	}
+	if !apResp.Success {
+		return nil, &LanPortsUnavailableError{MacAddr: macAddr}
+	}
+	ports := make([]ApLanPort, 0, len(apResp.Data.LanPorts))
+	for _, p := range apResp.Data.LanPorts {
+		ports = append(ports, p.lanPort())
+	}
+	return ports, nil
+}
+
+func (p apLanPortRES) lanPort() ApLanPort {
+	phyUp, speed, duplex := ParseLinkStatus(p.PhyLink)
+	logicUp, _, _ := ParseLinkStatus(p.LogicLink)
+	dot1x := strings.TrimSpace(p.Dot1x)
+	lp := ApLanPort{
+		MacAddr:     p.MacAddr,
+		Name:        p.Port,
+		Intf:        p.Intf,
+		Enabled:     true,
+		PhyLink:     p.PhyLink,
+		PhyLinkUp:   phyUp,
+		SpeedMbps:   speed,
+		Duplex:      duplex,
+		LogicLink:   p.LogicLink,
+		LogicLinkUp: logicUp,
+		PoEIn:       p.PoEIn,
+		PoEOut:      p.PoEOut,
+		VlanUntagID: p.VlanUntagID,
+		VlanMembers: p.VlanMembers,
+		Profile:     p.Profile,
+		Dot1x:       dot1x,
+	}
+	if p.Enabled != nil {
+		lp.Enabled = *p.Enabled
+	}
+	switch strings.ToLower(dot1x) {
+	case "", "disabled", "disable", "none", "off":
+	default:
+		lp.Dot1xEnabled = true
+	}
+	return lp
+}
+
+var linkSpeedRe = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([mg])(?:b(?:ps|/s)?)?$`)
+
+// ParseLinkStatus parses a Link Status as reported by the Controller
+// such as "Up 1000Mbps full", "Up 2.5Gbps", "up 100 Mbps half" or "Down"
+// Parts that are missing are returned as their zero value
+func ParseLinkStatus(s string) (up bool, speedMbps int, duplex Duplex) {
+	fields := strings.Fields(strings.ToLower(s))
+	for i := 0; i < len(fields); i++ {
+		f := strings.Trim(fields[i], ",;()")
+		switch f {
+		case "up":
+			up = true
+			continue
+		case "full", "full-duplex", "fdx", "fd":
+			duplex = DuplexFull
+			continue
+		case "half", "half-duplex", "hdx", "hd":
+			duplex = DuplexHalf
+			continue
+		}
+		// the Unit may be separated from the Value ("100 Mbps")
+		if _, err := strconv.ParseFloat(f, 64); err == nil && i+1 < len(fields) {
+			if m := linkSpeedRe.FindStringSubmatch(f + fields[i+1]); m != nil {
+				speedMbps = toMbps(m[1], m[2])
+				i++
+				continue
+			}
+		}
+		if m := linkSpeedRe.FindStringSubmatch(f); m != nil {
+			speedMbps = toMbps(m[1], m[2])
+		}
+	}
+	return up, speedMbps, duplex
+}
+
+func toMbps(value, unit string) int {
+	v, _ := strconv.ParseFloat(value, 64)
+	if unit == "g" {
+		v *= 1000
+	}
+	return int(v + 0.5)
+}
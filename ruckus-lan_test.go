@@ -0,0 +1,69 @@
+package ruckus
+
+import "testing"
+
+func TestParseLinkStatus(t *testing.T) {
+	tests := []struct {
+		in     string
+		up     bool
+		speed  int
+		duplex Duplex
+	}{
+		{"Up 1000Mbps full", true, 1000, DuplexFull},
+		{"Up", true, 0, DuplexUnknown},
+		{"Down", false, 0, DuplexUnknown},
+		{"Up 2.5Gbps", true, 2500, DuplexUnknown},
+		{"up 100 Mbps half", true, 100, DuplexHalf},
+		{"", false, 0, DuplexUnknown},
+		{"%$# not a link status", false, 0, DuplexUnknown},
+	}
+	for _, tt := range tests {
+		up, speed, duplex := ParseLinkStatus(tt.in)
+		if up != tt.up || speed != tt.speed || duplex != tt.duplex {
+			t.Errorf("ParseLinkStatus(%q) = %v, %d, %q; want %v, %d, %q",
+				tt.in, up, speed, duplex, tt.up, tt.speed, tt.duplex)
+		}
+	}
+}
+
+const lanPortsBody = `{"success":true,"data":{"lanPortStatus":[
+	{"apMac":"aa:bb","port":"LAN1","interface":"eth0","phyLink":"Down","logicLink":"Down"},
+	{"apMac":"aa:bb","port":"LAN2","interface":"eth1","phyLink":"Up 1000Mbps full","logicLink":"Up","poeStatus":"802.3at","dot1x":"Disabled"}]}}`
+
+func TestGetApLanPorts(t *testing.T) {
+	c, _ := newRetryClient(fakeOutcome{status: 200, body: lanPortsBody})
+	ports, err := c.GetApLanPorts("aa:bb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ports) != 2 {
+		t.Fatalf("got %d ports; want 2", len(ports))
+	}
+	p := ports[1]
+	if p.Name != "LAN2" || p.Intf != "eth1" || !p.PhyLinkUp || p.SpeedMbps != 1000 || p.Duplex != DuplexFull || !p.LogicLinkUp || p.PoEIn != "802.3at" {
+		t.Errorf("LAN2 = %+v", p)
+	}
+
+	intf, err := c.GetApIntf("aa:bb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if intf != (ApIntf{MacAddr: "aa:bb", Speed: "1000Mbps", Duplex: "FULL", Status: "up"}) {
+		t.Errorf("GetApIntf() = %+v", intf)
+	}
+}
+
+func TestGetApLanPortsUnavailable(t *testing.T) {
+	for _, body := range []string{`{"success":false}`, `<html>Service Unavailable</html>`} {
+		c, _ := newRetryClient(fakeOutcome{status: 200, body: body})
+		_, err := c.GetApLanPorts("aa:bb")
+		if _, ok := err.(*LanPortsUnavailableError); !ok {
+			t.Errorf("%s: GetApLanPorts() = %v; want a LanPortsUnavailableError", body, err)
+		}
+		// GetApIntf keeps reporting such an AP with an empty ApIntf
+		intf, err := c.GetApIntf("aa:bb")
+		if err != nil || intf != (ApIntf{}) {
+			t.Errorf("%s: GetApIntf() = %+v, %v; want an empty ApIntf and no error", body, intf, err)
+		}
+	}
+}
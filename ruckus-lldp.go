@@ -0,0 +1,145 @@
+package ruckus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ApLldpNeighbor a Device seen by an AP over LLDP
+type ApLldpNeighbor struct {
+	// the AP Interface the Neighbor was seen on (eth0|eth1...)
+	LocalIntf      string
+	LocalIntfIndex int
+	// the subtype of ChassisID (mac|ip|local...)
+	ChassisIDType string
+	ChassisID     string
+	SysName       string
+	SysDesc       string
+	MgmtIP        string
+	Capabilities  []LldpCapability
+	// the subtype of PortID (ifname|mac|local...)
+	PortIDType string
+	PortID     string
+	PortDesc   string
+	// seconds the Neighbor information remains valid
+	TTL int
+	// the time since the Neighbor was last updated (as reported)
+	Age string
+	PoE LldpPoE
+}
+
+// LldpCapability a System Capability advertised by an LLDP Neighbor
+type LldpCapability struct {
+	Name    string
+	Enabled bool
+}
+
+// LldpPoE the Power via MDI information exchanged with an LLDP Neighbor
+type LldpPoE struct {
+	DeviceType string
+	PowerType  string
+	Source     string
+	Priority   string
+	Class      string
+	Requested  string
+	Allocated  string
+}
+
+type apLldpNeighborRES struct {
+	Intf           string `json:"lldpInterface"`
+	IntfIndex      int    `json:"lldpInterfaceIndex"`
+	Time           string `json:"lldpTime"`
+	ChassisID      string `json:"lldpChassisID"`
+	SysName        string `json:"lldpSysName"`
+	SysDesc        string `json:"lldpSysDesc"`
+	MgmtIP         string `json:"lldpMgmtIP"`
+	Capability     string `json:"lldpCapability"`
+	PortID         string `json:"lldpPortID"`
+	PortDesc       string `json:"lldpPortDesc"`
+	TTL            string `json:"lldpTTL"`
+	PDDevice       string `json:"lldpPDDevice"`
+	PowerType      string `json:"lldpPowerType"`
+	PowerSource    string `json:"lldpPowerSource"`
+	PowerPriority  string `json:"lldpPowerPriority"`
+	PowerClass     string `json:"lldpPowerClass"`
+	PowerRequested string `json:"lldpPowerRequested"`
+	PowerAllocated string `json:"lldpPowerAllocated"`
+}
+
+// GetApLldpNeighbors retrieves every LLDP Neighbor of an AP across all its Ports
+func (c *Client) GetApLldpNeighbors(macAddr string) ([]ApLldpNeighbor, error) {
+	var list []apLldpNeighborRES
+	if err := c.getList(fmt.Sprintf("/aps/%s/apLldpNeighbors", macAddr), RksOptions{}, &list); err != nil {
+		return nil, err
+	}
+	var neighbors []ApLldpNeighbor
+	for _, n := range list {
+		neighbors = append(neighbors, n.neighbor())
+	}
+	return neighbors, nil
+}
+
+func (n apLldpNeighborRES) neighbor() ApLldpNeighbor {
+	chassisType, chassisID := splitLldpID(n.ChassisID)
+	portType, portID := splitLldpID(n.PortID)
+	ttl, _ := strconv.Atoi(strings.TrimSpace(n.TTL))
+	return ApLldpNeighbor{
+		LocalIntf:      strings.TrimSpace(n.Intf),
+		LocalIntfIndex: n.IntfIndex,
+		ChassisIDType:  chassisType,
+		ChassisID:      chassisID,
+		SysName:        strings.TrimSpace(n.SysName),
+		SysDesc:        strings.TrimSpace(n.SysDesc),
+		MgmtIP:         strings.TrimSpace(n.MgmtIP),
+		Capabilities:   parseLldpCapabilities(n.Capability),
+		PortIDType:     portType,
+		PortID:         portID,
+		PortDesc:       strings.TrimSpace(n.PortDesc),
+		TTL:            ttl,
+		Age:            strings.TrimSpace(n.Time),
+		PoE: LldpPoE{
+			DeviceType: n.PDDevice,
+			PowerType:  n.PowerType,
+			Source:     n.PowerSource,
+			Priority:   n.PowerPriority,
+			Class:      n.PowerClass,
+			Requested:  n.PowerRequested,
+			Allocated:  n.PowerAllocated,
+		},
+	}
+}
+
+// lldpIDTypes the subtypes the AP prefixes Chassis and Port IDs with
+var lldpIDTypes = map[string]bool{
+	"ifname":  true,
+	"ifalias": true,
+	"mac":     true,
+	"ip":      true,
+	"local":   true,
+}
+
+// splitLldpID separates the subtype from an ID such as "ifname Gi1/0/1"
+func splitLldpID(id string) (string, string) {
+	id = strings.TrimSpace(id)
+	fields := strings.SplitN(id, " ", 2)
+	if len(fields) == 2 && lldpIDTypes[strings.ToLower(fields[0])] {
+		return strings.ToLower(fields[0]), strings.TrimSpace(fields[1])
+	}
+	return "", id
+}
+
+var lldpCapRe = regexp.MustCompile(`([A-Za-z][A-Za-z ]*?)\s*,\s*(on|off)`)
+
+// parseLldpCapabilities parses capabilities reported as "Bridge, on Router, off"
+func parseLldpCapabilities(s string) []LldpCapability {
+	var caps []LldpCapability
+	for _, m := range lldpCapRe.FindAllStringSubmatch(s, -1) {
+		caps = append(caps, LldpCapability{
+			Name:    strings.TrimSpace(m[1]),
+			Enabled: m[2] == "on",
+		})
+	}
+	return caps
+}
@@ -0,0 +1,53 @@
+package ruckus
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetApLldpNeighbors(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("GET /aps/aa:bb/apLldpNeighbors",
+		`{"totalCount":2,"hasMore":true,"firstIndex":0,"list":[{"lldpInterface":"eth0","lldpChassisID":"mac 00:11:22:33:44:55","lldpSysName":" sw1 ","lldpMgmtIP":"10.0.0.2","lldpCapability":"Bridge, on Router, off","lldpPortID":"ifname Gi1/0/1","lldpTTL":"120"}]}`,
+		`{"totalCount":2,"hasMore":false,"firstIndex":1,"list":[{"lldpInterface":"eth1","lldpChassisID":"local phone-7","lldpSysName":"phone","lldpPortID":"port 1"}]}`)
+
+	neighbors, err := c.GetApLldpNeighbors("aa:bb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(neighbors) != 2 {
+		t.Fatalf("got %d neighbors; want both pages", len(neighbors))
+	}
+	sw := neighbors[0]
+	want := ApLldpNeighbor{
+		LocalIntf:     "eth0",
+		ChassisIDType: "mac",
+		ChassisID:     "00:11:22:33:44:55",
+		SysName:       "sw1",
+		MgmtIP:        "10.0.0.2",
+		Capabilities:  []LldpCapability{{"Bridge", true}, {"Router", false}},
+		PortIDType:    "ifname",
+		PortID:        "Gi1/0/1",
+		TTL:           120,
+	}
+	if !reflect.DeepEqual(sw, want) {
+		t.Errorf("neighbor = %+v; want %+v", sw, want)
+	}
+	if p := neighbors[1]; p.LocalIntf != "eth1" || p.ChassisIDType != "local" || p.PortIDType != "" || p.PortID != "port 1" {
+		t.Errorf("second neighbor = %+v", p)
+	}
+}
+
+func TestGetApLldpNeighborsError(t *testing.T) {
+	f, c := newFakeController(t)
+	f.onStatus("GET /aps/aa:bb/apLldpNeighbors", http.StatusNotFound, `{"message":"AP not found"}`)
+
+	neighbors, err := c.GetApLldpNeighbors("aa:bb")
+	if rksErr, ok := err.(*RksError); !ok || rksErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("GetApLldpNeighbors() = %v, %v; want an RksError 404", neighbors, err)
+	}
+	if _, err := c.GetApLldp("aa:bb"); err == nil {
+		t.Errorf("GetApLldp() hid the error")
+	}
+}
@@ -0,0 +1,115 @@
+package ruckus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Patch returns the minimal JSON Merge Patch (RFC 7386) turning original
+// into modified: only the fields whose value changed, nested objects being
+// patched field by field and lists replaced as a whole.
+// A field original holds that modified leaves out (ie an omitempty zero
+// value) is patched to its zero value: "", 0, false, [] or null for an
+// object. A field modified sets to null (a nil pointer, or nil in a
+// map[string]interface{}) while original holds a value is patched to null,
+// which SmartZone takes as a reset to its default; null and an empty
+// list|object differ. A nil original patches every field of modified.
+// The patch is {} when nothing changed
+func Patch(original, modified interface{}) (json.RawMessage, error) {
+	ov, err := toJSONValue(original)
+	if err != nil {
+		return nil, err
+	}
+	mv, err := toJSONValue(modified)
+	if err != nil {
+		return nil, err
+	}
+	p, changed := mergePatch(ov, mv)
+	if !changed {
+		return json.RawMessage("{}"), nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(p); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func mergePatch(o, m interface{}) (interface{}, bool) {
+	om, oObj := o.(map[string]interface{})
+	mm, mObj := m.(map[string]interface{})
+	if oObj && mObj {
+		patch := make(map[string]interface{})
+		for k, ov := range om {
+			if _, ok := mm[k]; !ok && ov != nil {
+				if z := zeroJSON(ov); !reflect.DeepEqual(z, ov) {
+					patch[k] = z
+				}
+			}
+		}
+		for k, mv := range mm {
+			ov, ok := om[k]
+			if !ok {
+				if mv != nil {
+					patch[k] = mv
+				}
+				continue
+			}
+			if p, changed := mergePatch(ov, mv); changed {
+				patch[k] = p
+			}
+		}
+		return patch, len(patch) > 0
+	}
+	if reflect.DeepEqual(o, m) {
+		return nil, false
+	}
+	return m, true
+}
+
+// zeroJSON the JSON of the Go zero value of a field holding v
+func zeroJSON(v interface{}) interface{} {
+	switch v.(type) {
+	case string:
+		return ""
+	case json.Number:
+		return json.Number("0")
+	case bool:
+		return false
+	case []interface{}:
+		return []interface{}{}
+	}
+	return nil
+}
+
+// update PATCHes the resource at ep with the fields changes sets, as given.
+// A field changes leaves out (ie an omitempty zero value) or sets to null
+// is left unchanged so it cannot be cleared this way; see patch
+func (c *Client) update(ep string, changes interface{}) error {
+	return c.patch(ep, json.RawMessage("{}"), changes)
+}
+
+// patch PATCHes the resource at ep with the fields of modified that differ
+// from original (see Patch); nothing is sent when none do. original is the
+// resource as retrieved by the caller: the resource is not read again so
+// the fields changed since by someone else are left alone
+func (c *Client) patch(ep string, original, modified interface{}) error {
+	body, err := Patch(original, modified)
+	if err != nil {
+		return err
+	}
+	if string(body) == "{}" {
+		return nil
+	}
+	return c.send("PATCH", ep, RksOptions{}, body, nil)
+}
+
+// PatchZone PATCHes the fields of modified that differ from original
+// (see Patch); original is the Zone as retrieved
+func (c *Client) PatchZone(original, modified RksZone) error {
+	return c.patch(fmt.Sprintf("/rkszones/%s", original.ID), original, modified)
+}
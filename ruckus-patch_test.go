@@ -0,0 +1,106 @@
+package ruckus
+
+import "testing"
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		original, modified string
+		want               string
+	}{
+		{"unchanged", `{"a":1,"b":{"c":"x"}}`, `{"a":1,"b":{"c":"x"}}`, `{}`},
+		{"changed nested field", `{"a":1,"b":{"c":"x","d":2}}`, `{"a":1,"b":{"c":"y","d":2}}`, `{"b":{"c":"y"}}`},
+		{"null to empty list", `{"l":null}`, `{"l":[]}`, `{"l":[]}`},
+		{"empty list to null", `{"l":[]}`, `{"l":null}`, `{"l":null}`},
+		{"reset to null", `{"p":{"id":"x"}}`, `{"p":null}`, `{"p":null}`},
+		{"list left out", `{"name":"n","l":["a"]}`, `{"name":"n"}`, `{"l":[]}`},
+		{"fields left out", `{"s":"x","n":5,"b":true,"o":{"id":"x"}}`, `{}`, `{"b":false,"n":0,"o":null,"s":""}`},
+		{"null not added", `{}`, `{"p":null}`, `{}`},
+		{"no original", `null`, `{"a":1,"p":null}`, `{"a":1,"p":null}`},
+	}
+	for _, tt := range tests {
+		got, err := Patch([]byte(tt.original), []byte(tt.modified))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: Patch = %s; want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPatchHotspotClearsWalledGarden(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("PATCH /rkszones/z1/portals/hotspot/internal/h1", ``)
+
+	original := RksHotspot{ID: "h1", Name: "lobby", WalledGardens: []string{"www.example.com"}}
+	modified := original
+	modified.WalledGardens = nil
+	if err := c.PatchHotspot("z1", original, modified); err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.received("")
+	if len(reqs) != 1 || reqs[0].Method != "PATCH" {
+		t.Fatalf("requests = %+v; want a single PATCH", reqs)
+	}
+	if reqs[0].Body != `{"walledGardens":[]}` {
+		t.Errorf("body = %s", reqs[0].Body)
+	}
+}
+
+func TestUpdateDoesNotRead(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("PATCH /vlanpoolings/p1", ``)
+
+	if err := c.UpdateVlanPool("p1", RksVlanPool{Description: "guests"}); err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.received("")
+	if len(reqs) != 1 || reqs[0].Method != "PATCH" {
+		t.Fatalf("requests = %+v; want a single PATCH", reqs)
+	}
+	if reqs[0].Body != `{"description":"guests"}` {
+		t.Errorf("body = %s", reqs[0].Body)
+	}
+}
+
+func TestPatchWlanSendsOnlyTheChange(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("GET /rkszones/z1/wlans/w1",
+		`{"id":"w1","zoneId":"z1","name":"staff","ssid":"staff","vlan":{"accessVlan":10},"bypassCNA":true}`)
+	f.on("PATCH /rkszones/z1/wlans/w1", ``)
+
+	original, err := c.GetWlan("z1", "w1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modified := original
+	modified.SSID = "staff-5g"
+	if err := c.PatchWlan("z1", original, modified); err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.received("PATCH /rkszones/z1/wlans/w1")
+	if len(reqs) != 1 {
+		t.Fatalf("requests = %+v; want a single PATCH", reqs)
+	}
+	if reqs[0].Body != `{"ssid":"staff-5g"}` {
+		t.Errorf("body = %s; want only the SSID", reqs[0].Body)
+	}
+}
+
+func TestUpdateSendsNoNulls(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("PATCH /userGroups/g1", ``)
+
+	perms := []RksPermission{{Resource: "WLAN", Access: "READ"}}
+	if err := c.SetUserGroupScope("g1", perms, nil); err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.received("")
+	if len(reqs) != 1 || reqs[0].Method != "PATCH" {
+		t.Fatalf("requests = %+v; want a single PATCH", reqs)
+	}
+	if want := `{"permissions":[{"access":"READ","resource":"WLAN"}]}`; reqs[0].Body != want {
+		t.Errorf("body = %s; want %s", reqs[0].Body, want)
+	}
+}
@@ -0,0 +1,273 @@
+package ruckus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RksHotspot properties of a Hotspot (WISPr) Portal of a Zone
+// the Portal is hosted by the Controller (internal) or an External Portal
+type RksHotspot struct {
+	ID          string              `json:"id,omitempty"`
+	ZoneID      string              `json:"zoneId,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Logon       *RksHotspotLogon    `json:"logonUrl,omitempty"`
+	Redirect    *RksPortalRedirect  `json:"redirect,omitempty"`
+	UserSession *RksPortalSession   `json:"userSession,omitempty"`
+	Location    *RksHotspotLocation `json:"location,omitempty"`
+	// ie NONE|ENABLED|ONLY
+	SmartClientSupport string `json:"smartClientSupport,omitempty"`
+	// Hosts|Networks Clients reach before authenticating
+	// (ie www.example.com, *.example.com, 10.0.0.0/8, 10.1.1.1-10.1.1.9)
+	WalledGardens []string `json:"walledGardens,omitempty"`
+}
+
+// RksHotspotLogon where Clients log on to a Hotspot
+type RksHotspotLogon struct {
+	IsExternal bool `json:"isExternal"`
+	// the URL of the External Portal
+	External string `json:"external,omitempty"`
+}
+
+// RksHotspotLocation the WISPr Location of a Hotspot
+type RksHotspotLocation struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// RksWebAuth properties of a Web Authentication Portal of a Zone
+type RksWebAuth struct {
+	ID          string             `json:"id,omitempty"`
+	ZoneID      string             `json:"zoneId,omitempty"`
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Language    string             `json:"portalLanguage,omitempty"`
+	Redirect    *RksPortalRedirect `json:"redirect,omitempty"`
+	UserSession *RksPortalSession  `json:"userSession,omitempty"`
+}
+
+// Endpoints of the Portals of a Zone (formatted with the Zone ID)
+const (
+	hotspotEp  = "/rkszones/%s/portals/hotspot/internal"
+	externalEp = "/rkszones/%s/portals/hotspot/external"
+	webAuthEp  = "/rkszones/%s/portals/webauth"
+)
+
+// GetHotspots retrieves the (internal) Hotspot Portals of a Zone
+func (c *Client) GetHotspots(zoneID string) ([]RksHotspot, error) {
+	var hs []RksHotspot
+	err := c.getList(fmt.Sprintf(hotspotEp, zoneID), RksOptions{}, &hs)
+	return hs, err
+}
+
+// GetHotspot retrieves an (internal) Hotspot Portal of a Zone
+func (c *Client) GetHotspot(zoneID, id string) (RksHotspot, error) {
+	var h RksHotspot
+	err := c.send("GET", fmt.Sprintf(hotspotEp, zoneID)+"/"+id, RksOptions{}, nil, &h)
+	return h, err
+}
+
+// CreateHotspot creates an (internal) Hotspot Portal in a Zone returning its ID
+func (c *Client) CreateHotspot(zoneID string, h RksHotspot) (string, error) {
+	return c.createPortal(fmt.Sprintf(hotspotEp, zoneID), h)
+}
+
+// UpdateHotspot modifies the fields of an (internal) Hotspot Portal set in h
+func (c *Client) UpdateHotspot(zoneID, id string, h RksHotspot) error {
+	return c.update(fmt.Sprintf(hotspotEp, zoneID)+"/"+id, h)
+}
+
+// PatchHotspot PATCHes the fields of an (internal) Hotspot Portal that differ
+// from original, the Portal as retrieved (see Patch); unlike UpdateHotspot
+// it clears the fields modified leaves empty (ie the Walled Garden)
+func (c *Client) PatchHotspot(zoneID string, original, modified RksHotspot) error {
+	return c.patch(fmt.Sprintf(hotspotEp, zoneID)+"/"+original.ID, original, modified)
+}
+
+// DeleteHotspot removes an (internal) Hotspot Portal from a Zone
+func (c *Client) DeleteHotspot(zoneID, id string) error {
+	return c.send("DELETE", fmt.Sprintf(hotspotEp, zoneID)+"/"+id, RksOptions{}, nil, nil)
+}
+
+// GetExternalPortals retrieves the External (WISPr) Portals of a Zone
+func (c *Client) GetExternalPortals(zoneID string) ([]RksHotspot, error) {
+	var hs []RksHotspot
+	err := c.getList(fmt.Sprintf(externalEp, zoneID), RksOptions{}, &hs)
+	return hs, err
+}
+
+// GetExternalPortal retrieves an External Portal of a Zone
+func (c *Client) GetExternalPortal(zoneID, id string) (RksHotspot, error) {
+	var h RksHotspot
+	err := c.send("GET", fmt.Sprintf(externalEp, zoneID)+"/"+id, RksOptions{}, nil, &h)
+	return h, err
+}
+
+// CreateExternalPortal creates an External Portal in a Zone returning its ID
+func (c *Client) CreateExternalPortal(zoneID string, h RksHotspot) (string, error) {
+	return c.createPortal(fmt.Sprintf(externalEp, zoneID), h)
+}
+
+// UpdateExternalPortal modifies the fields of an External Portal set in h
+func (c *Client) UpdateExternalPortal(zoneID, id string, h RksHotspot) error {
+	return c.update(fmt.Sprintf(externalEp, zoneID)+"/"+id, h)
+}
+
+// PatchExternalPortal PATCHes the fields of an External Portal that differ
+// from original, the Portal as retrieved (see Patch); unlike
+// UpdateExternalPortal it clears the fields modified leaves empty
+func (c *Client) PatchExternalPortal(zoneID string, original, modified RksHotspot) error {
+	return c.patch(fmt.Sprintf(externalEp, zoneID)+"/"+original.ID, original, modified)
+}
+
+// DeleteExternalPortal removes an External Portal from a Zone
+func (c *Client) DeleteExternalPortal(zoneID, id string) error {
+	return c.send("DELETE", fmt.Sprintf(externalEp, zoneID)+"/"+id, RksOptions{}, nil, nil)
+}
+
+// GetWebAuths retrieves the Web Authentication Portals of a Zone
+func (c *Client) GetWebAuths(zoneID string) ([]RksWebAuth, error) {
+	var was []RksWebAuth
+	err := c.getList(fmt.Sprintf(webAuthEp, zoneID), RksOptions{}, &was)
+	return was, err
+}
+
+// GetWebAuth retrieves a Web Authentication Portal of a Zone
+func (c *Client) GetWebAuth(zoneID, id string) (RksWebAuth, error) {
+	var wa RksWebAuth
+	err := c.send("GET", fmt.Sprintf(webAuthEp, zoneID)+"/"+id, RksOptions{}, nil, &wa)
+	return wa, err
+}
+
+// CreateWebAuth creates a Web Authentication Portal in a Zone returning its ID
+func (c *Client) CreateWebAuth(zoneID string, wa RksWebAuth) (string, error) {
+	return c.createPortal(fmt.Sprintf(webAuthEp, zoneID), wa)
+}
+
+// UpdateWebAuth modifies the fields of a Web Authentication Portal set in wa
+func (c *Client) UpdateWebAuth(zoneID, id string, wa RksWebAuth) error {
+	return c.update(fmt.Sprintf(webAuthEp, zoneID)+"/"+id, wa)
+}
+
+// PatchWebAuth PATCHes the fields of a Web Authentication Portal that differ
+// from original, the Portal as retrieved (see Patch)
+func (c *Client) PatchWebAuth(zoneID string, original, modified RksWebAuth) error {
+	return c.patch(fmt.Sprintf(webAuthEp, zoneID)+"/"+original.ID, original, modified)
+}
+
+// DeleteWebAuth removes a Web Authentication Portal from a Zone
+func (c *Client) DeleteWebAuth(zoneID, id string) error {
+	return c.send("DELETE", fmt.Sprintf(webAuthEp, zoneID)+"/"+id, RksOptions{}, nil, nil)
+}
+
+func (c *Client) createPortal(ep string, p interface{}) (string, error) {
+	var created RksObject
+	err := c.send("POST", ep, RksOptions{}, p, &created)
+	return created.ID, err
+}
+
+// SetWalledGarden replaces the Walled Garden of an (internal) Hotspot Portal
+func (c *Client) SetWalledGarden(zoneID, id string, entries []string) error {
+	return c.setWalledGarden(fmt.Sprintf(hotspotEp, zoneID)+"/"+id, entries)
+}
+
+// SetExternalWalledGarden replaces the Walled Garden of an External Portal
+func (c *Client) SetExternalWalledGarden(zoneID, id string, entries []string) error {
+	return c.setWalledGarden(fmt.Sprintf(externalEp, zoneID)+"/"+id, entries)
+}
+
+func (c *Client) setWalledGarden(ep string, entries []string) error {
+	if entries == nil {
+		// an empty Walled Garden is sent as such rather than omitted
+		entries = []string{}
+	}
+	body := struct {
+		WalledGardens []string `json:"walledGardens"`
+	}{entries}
+	return c.send("PATCH", ep, RksOptions{}, body, nil)
+}
+
+// WalledGardenResult the outcome of syncing the Walled Garden of one Portal
+type WalledGardenResult struct {
+	ZoneID     string
+	PortalID   string
+	PortalName string
+	Added      []string
+	Removed    []string
+	Err        error
+}
+
+// WalledGardenSync makes the Walled Garden of every Hotspot and External
+// Portal of zoneIDs identical to entries; Portals already matching are not
+// updated (nor reported); entries are compared ignoring case and sent as
+// given. The returned error is only set when the Portals
+// of a Zone could not be retrieved
+func (c *Client) WalledGardenSync(zoneIDs, entries []string) ([]WalledGardenResult, error) {
+	var results []WalledGardenResult
+	for _, zoneID := range zoneIDs {
+		internal, err := c.GetHotspots(zoneID)
+		if err != nil {
+			return results, fmt.Errorf("failed to get hotspots of zone %s: %v", zoneID, err)
+		}
+		external, err := c.GetExternalPortals(zoneID)
+		if err != nil {
+			return results, fmt.Errorf("failed to get external portals of zone %s: %v", zoneID, err)
+		}
+		for i, h := range append(internal, external...) {
+			// Lists only summarize the Portals; the Walled Garden needs the Portal itself
+			get, set := c.GetHotspot, c.SetWalledGarden
+			if i >= len(internal) {
+				get, set = c.GetExternalPortal, c.SetExternalWalledGarden
+			}
+			full, err := get(zoneID, h.ID)
+			if err != nil {
+				return results, fmt.Errorf("failed to get portal %s: %v", h.Name, err)
+			}
+			added, removed := diffEntries(full.WalledGardens, entries)
+			if len(added) == 0 && len(removed) == 0 {
+				continue
+			}
+			r := WalledGardenResult{ZoneID: zoneID, PortalID: h.ID, PortalName: h.Name, Added: added, Removed: removed}
+			r.Err = set(zoneID, h.ID, entries)
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+// entryKey the form Walled Garden entries are compared in
+func entryKey(e string) string {
+	return strings.ToLower(strings.TrimSpace(e))
+}
+
+// diffEntries the entries of want missing from have and those of have not
+// in want, compared ignoring case and surrounding spaces
+func diffEntries(have, want []string) (added, removed []string) {
+	h := make(map[string]bool, len(have))
+	for _, e := range have {
+		h[entryKey(e)] = true
+	}
+	w := make(map[string]bool, len(want))
+	for _, e := range want {
+		k := entryKey(e)
+		if k == "" || w[k] {
+			continue
+		}
+		w[k] = true
+		if !h[k] {
+			added = append(added, e)
+		}
+	}
+	for _, e := range have {
+		if k := entryKey(e); k != "" && !w[k] {
+			removed = append(removed, e)
+			// report an entry listed twice once
+			w[k] = true
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
@@ -0,0 +1,46 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWalledGardenSync(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("GET /rkszones/z1/portals/hotspot/internal", `{"list":[{"id":"h1","name":"lobby"}]}`)
+	f.on("GET /rkszones/z1/portals/hotspot/external", `{"list":[{"id":"e1","name":"cafe"}]}`)
+	// already matching once case is ignored
+	f.on("GET /rkszones/z1/portals/hotspot/internal/h1", `{"id":"h1","walledGardens":["www.example.com","*.CDN.net"]}`)
+	f.on("GET /rkszones/z1/portals/hotspot/external/e1", `{"id":"e1","walledGardens":["old.example.com"]}`)
+	f.on("PATCH /rkszones/z1/portals/hotspot/external/e1", ``)
+
+	entries := []string{"WWW.Example.com", "*.cdn.net"}
+	results, err := c.WalledGardenSync([]string{"z1"}, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].PortalID != "e1" || results[0].Err != nil {
+		t.Fatalf("results = %+v; want only the external portal updated", results)
+	}
+	if !reflect.DeepEqual(results[0].Removed, []string{"old.example.com"}) {
+		t.Errorf("removed = %v", results[0].Removed)
+	}
+	patched := make(map[string][]string)
+	for _, r := range f.received("") {
+		if r.Method != "PATCH" {
+			continue
+		}
+		var body struct {
+			WalledGardens []string `json:"walledGardens"`
+		}
+		if err := json.Unmarshal([]byte(r.Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		patched[r.Path] = body.WalledGardens
+	}
+	want := map[string][]string{"/rkszones/z1/portals/hotspot/external/e1": entries}
+	if !reflect.DeepEqual(patched, want) {
+		t.Errorf("patched %v; want %v", patched, want)
+	}
+}
@@ -0,0 +1,137 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const defaultQueryLimit = 1000
+
+// newQuery creates a Query retrieving all attributes sorted by sortCol
+func newQuery(sortCol string) RksQuery {
+	return RksQuery{
+		Filters:       []Mapper{},
+		FullTxtSearch: Mapper{Type: "AND", Value: ""},
+		Attrs:         []string{"*"},
+		SortInfo:      rksSortInfo{SortCol: sortCol, Direction: "ASC"},
+		Page:          1,
+		Limit:         defaultQueryLimit,
+	}
+}
+
+// scopeQuery adds a DOMAIN Filter to q for the Domain of o|the Client
+// Query endpoints are scoped by their Filters rather than by domainId
+func (c *Client) scopeQuery(q *RksQuery, o RksOptions) {
+	id := o.DomainID
+	if id == "" {
+		id = c.domainID
+	}
+	if id == "" {
+		return
+	}
+	for _, f := range q.Filters {
+		if f.Type == "DOMAIN" {
+			return
+		}
+	}
+	// q shares its Filters with the caller
+	q.Filters = append(append([]Mapper(nil), q.Filters...), Mapper{Type: "DOMAIN", Value: id})
+}
+
+// queryList POSTs q to a Query endpoint following every page of results
+// list must be a pointer to a slice; each page is appended to it
+func (c *Client) queryList(ep string, q RksQuery, o RksOptions, list interface{}) error {
+	if c.ticket() == "" {
+		return fmt.Errorf(loginErr)
+	}
+	lv := reflect.ValueOf(list)
+	if lv.Kind() != reflect.Ptr || lv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("list must be a pointer to a slice")
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	c.scopeQuery(&q, o)
+	for {
+		qjson, _ := json.Marshal(&q)
+		req, err := http.NewRequest("POST", c.BaseURL+ep, strings.NewReader(string(qjson)))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+		req.Header.Add("Content-Type", "application/json;charset=UTF-8")
+		c.addQS(req, o)
+		// Queries only read; they are safe to retry despite being a POST
+		res, err := c.doRetry(req, true)
+		if err != nil {
+			return fmt.Errorf("failed to get resp: %v", err)
+		}
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			err = newRksError(res)
+			res.Body.Close()
+			return err
+		}
+		var result struct {
+			RksCommonReq
+			List json.RawMessage `json:"list"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+		n, err := appendPage(lv, result.List)
+		if err != nil {
+			return err
+		}
+		if !result.HasMore || n == 0 {
+			return nil
+		}
+		q.Page++
+	}
+}
+
+// getList GETs a List endpoint following every page of results
+// list must be a pointer to a slice; each page is appended to it
+func (c *Client) getList(ep string, o RksOptions, list interface{}) error {
+	lv := reflect.ValueOf(list)
+	if lv.Kind() != reflect.Ptr || lv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("list must be a pointer to a slice")
+	}
+	if o.ListSize == "" {
+		o.ListSize = strconv.Itoa(defaultQueryLimit)
+	}
+	for {
+		var result struct {
+			RksCommonReq
+			List json.RawMessage `json:"list"`
+		}
+		if err := c.send("GET", ep, o, nil, &result); err != nil {
+			return err
+		}
+		n, err := appendPage(lv, result.List)
+		if err != nil {
+			return err
+		}
+		if !result.HasMore || n == 0 {
+			return nil
+		}
+		o.Index = strconv.Itoa(result.FirstIndex + n)
+	}
+}
+
+// appendPage decodes a page of results appending it to the slice lv points to
+func appendPage(lv reflect.Value, raw json.RawMessage) (int, error) {
+	if len(raw) == 0 {
+		return 0, nil
+	}
+	page := reflect.New(lv.Elem().Type())
+	if err := json.Unmarshal(raw, page.Interface()); err != nil {
+		return 0, err
+	}
+	lv.Elem().Set(reflect.AppendSlice(lv.Elem(), page.Elem()))
+	return page.Elem().Len(), nil
+}
@@ -0,0 +1,206 @@
+package ruckus
+
+import "fmt"
+
+// RadioOverride the Settings of a Radio to override on an AP;
+// a field that is not set is left as it is
+type RadioOverride struct {
+	// 0 selects the Channel automatically
+	Channel *int
+	// in MHz; 20|40 (2.4GHz) 20|40|80|160 (5GHz)
+	ChannelWidth *int
+	// ie Full|-1dB..-10dB|Min
+	TxPower     string
+	WlanGroupID string
+	Enabled     *bool
+}
+
+// Validate checks the Channel and Channel Width suit the band
+func (o RadioOverride) Validate(band RadioBand) error {
+	var widths []int
+	switch band {
+	case Band24:
+		if o.Channel != nil && (*o.Channel < 0 || *o.Channel > 14) {
+			return fmt.Errorf("invalid %s channel %d", band, *o.Channel)
+		}
+		widths = []int{20, 40}
+	case Band50:
+		if o.Channel != nil && *o.Channel != 0 && (*o.Channel < 36 || *o.Channel > 177) {
+			return fmt.Errorf("invalid %s channel %d", band, *o.Channel)
+		}
+		widths = []int{20, 40, 80, 160}
+	default:
+		return fmt.Errorf("invalid band %q", band)
+	}
+	if o.ChannelWidth != nil && !containsInt(widths, *o.ChannelWidth) {
+		return fmt.Errorf("invalid %s channel width %d", band, *o.ChannelWidth)
+	}
+	return nil
+}
+
+// bandSuffix the suffix SmartZone gives the fields of the band Radio
+func bandSuffix(band RadioBand) string {
+	if band == Band24 {
+		return "24"
+	}
+	return "50"
+}
+
+// SetApRadioConfig overrides the Settings of the band Radio of an AP
+// set in o; the others keep being inherited from its AP Group|Zone
+func (c *Client) SetApRadioConfig(macAddr string, band RadioBand, o RadioOverride) error {
+	if err := o.Validate(band); err != nil {
+		return err
+	}
+	sfx := bandSuffix(band)
+	body := make(map[string]interface{})
+	radio := RksApRadio{TxPower: o.TxPower, Channel: o.Channel, ChannelWidth: o.ChannelWidth}
+	if radio.TxPower != "" || radio.Channel != nil || radio.ChannelWidth != nil {
+		body["wifi"+sfx] = radio
+	}
+	if o.WlanGroupID != "" {
+		body["wlanGroup"+sfx] = map[string]string{"id": o.WlanGroupID}
+	}
+	if o.Enabled != nil {
+		body["wlanService"+sfx+"Enabled"] = *o.Enabled
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	return c.update(fmt.Sprintf("/aps/%s", macAddr), body)
+}
+
+// ClearApRadioOverrides resets (to null) every Radio Setting an AP overrides
+// so it inherits them from its AP Group|Zone again
+func (c *Client) ClearApRadioOverrides(macAddr string) error {
+	body := make(map[string]interface{})
+	for _, band := range radioBands {
+		sfx := bandSuffix(band)
+		body["wifi"+sfx] = nil
+		body["wlanGroup"+sfx] = nil
+		body["wlanService"+sfx+"Enabled"] = nil
+	}
+	return c.update(fmt.Sprintf("/aps/%s", macAddr), body)
+}
+
+// where an effective Radio Setting comes from
+const (
+	SourceAp      = "AP"
+	SourceApGroup = "AP Group"
+	SourceZone    = "Zone"
+)
+
+// EffectiveRadio the Settings a Radio of an AP operates with along with
+// where (AP|AP Group|Zone) each comes from
+type EffectiveRadio struct {
+	Band    RadioBand
+	Channel int
+	// the 5GHz Channel of an outdoor AP; the Zone sets one Channel for its
+	// indoor APs (Channel) and one for its outdoor APs while the AP does not
+	// say which it is, so both are returned. An AP|AP Group override sets both
+	OutdoorChannel     int
+	ChannelSource      string
+	ChannelWidth       int
+	ChannelWidthSource string
+	TxPower            string
+	TxPowerSource      string
+	// empty when the Zone default WLAN Group applies
+	WlanGroupID     string
+	WlanGroupSource string
+	Enabled         bool
+	EnabledSource   string
+}
+
+// radioLayer the Radio Settings an AP|AP Group may override
+type radioLayer struct {
+	source    string
+	radio     *RksApRadio
+	wlanGroup *RksObject
+	enabled   *bool
+}
+
+// GetEffectiveRadioConfig retrieves an AP along with its AP Group and Zone
+// and resolves the Settings of each Radio: the AP overrides its AP Group
+// which overrides the Zone
+func (c *Client) GetEffectiveRadioConfig(macAddr string) ([]EffectiveRadio, error) {
+	ap, err := c.GetApConfig(macAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ap: %v", err)
+	}
+	zone, err := c.GetZone(ap.ZoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zone: %v", err)
+	}
+	var grp RksApGroup
+	if ap.GroupID != "" {
+		if grp, err = c.GetApGroup(ap.ZoneID, ap.GroupID); err != nil {
+			return nil, fmt.Errorf("failed to get ap group: %v", err)
+		}
+	}
+	return resolveRadios(zone, grp, ap), nil
+}
+
+func resolveRadios(zone RksZone, grp RksApGroup, ap RksApConfig) []EffectiveRadio {
+	var radios []EffectiveRadio
+	for _, band := range radioBands {
+		layers := []radioLayer{
+			{SourceAp, bandRadio(band, ap.Wifi24, ap.Wifi50), bandObject(band, ap.WlanGroup24, ap.WlanGroup50), bandBool(band, ap.WlanService24Enabled, ap.WlanService50Enabled)},
+			{SourceApGroup, bandRadio(band, grp.Wifi24, grp.Wifi50), bandObject(band, grp.WlanGroup24, grp.WlanGroup50), bandBool(band, grp.WlanService24Enabled, grp.WlanService50Enabled)},
+		}
+		r := EffectiveRadio{
+			Band:               band,
+			ChannelSource:      SourceZone,
+			ChannelWidthSource: SourceZone,
+			TxPowerSource:      SourceZone,
+			WlanGroupSource:    SourceZone,
+			Enabled:            true,
+			EnabledSource:      SourceZone,
+		}
+		if band == Band24 {
+			r.Channel, r.ChannelWidth, r.TxPower = zone.Wifi24.Channel, zone.Wifi24.ChannelWidth, zone.Wifi24.TxPower
+		} else {
+			r.Channel, r.ChannelWidth, r.TxPower = zone.Wifi50.IndoorChannel, zone.Wifi50.ChannelWidth, zone.Wifi50.TxPower
+			r.OutdoorChannel = zone.Wifi50.OutdoorChannel
+		}
+		// walk from the Zone up so the AP is applied last
+		for i := len(layers) - 1; i >= 0; i-- {
+			l := layers[i]
+			if l.radio != nil {
+				if l.radio.Channel != nil {
+					r.Channel, r.ChannelSource = *l.radio.Channel, l.source
+					if band != Band24 {
+						r.OutdoorChannel = r.Channel
+					}
+				}
+				if l.radio.ChannelWidth != nil {
+					r.ChannelWidth, r.ChannelWidthSource = *l.radio.ChannelWidth, l.source
+				}
+				if l.radio.TxPower != "" {
+					r.TxPower, r.TxPowerSource = l.radio.TxPower, l.source
+				}
+			}
+			if l.wlanGroup != nil && l.wlanGroup.ID != "" {
+				r.WlanGroupID, r.WlanGroupSource = l.wlanGroup.ID, l.source
+			}
+			if l.enabled != nil {
+				r.Enabled, r.EnabledSource = *l.enabled, l.source
+			}
+		}
+		radios = append(radios, r)
+	}
+	return radios
+}
+
+func bandObject(band RadioBand, o24, o50 *RksObject) *RksObject {
+	if band == Band24 {
+		return o24
+	}
+	return o50
+}
+
+func bandBool(band RadioBand, b24, b50 *bool) *bool {
+	if band == Band24 {
+		return b24
+	}
+	return b50
+}
@@ -0,0 +1,32 @@
+package ruckus
+
+import "testing"
+
+func TestResolveRadios(t *testing.T) {
+	var zone RksZone
+	zone.Wifi24.Channel, zone.Wifi24.TxPower = 6, "Full"
+	zone.Wifi50.IndoorChannel, zone.Wifi50.OutdoorChannel, zone.Wifi50.TxPower = 36, 149, "Full"
+	width := 40
+	grp := RksApGroup{Wifi50: &RksApRadio{ChannelWidth: &width}}
+
+	radios := resolveRadios(zone, grp, RksApConfig{})
+	if len(radios) != 2 {
+		t.Fatalf("got %d radios", len(radios))
+	}
+	r50 := radios[1]
+	if r50.Channel != 36 || r50.OutdoorChannel != 149 || r50.ChannelSource != SourceZone {
+		t.Errorf("5GHz channels = %d/%d from %s; want the zone indoor 36 and outdoor 149", r50.Channel, r50.OutdoorChannel, r50.ChannelSource)
+	}
+	if r50.ChannelWidth != 40 || r50.ChannelWidthSource != SourceApGroup {
+		t.Errorf("5GHz width = %d from %s", r50.ChannelWidth, r50.ChannelWidthSource)
+	}
+
+	ch := 100
+	radios = resolveRadios(zone, grp, RksApConfig{Wifi50: &RksApRadio{Channel: &ch}})
+	if r50 := radios[1]; r50.Channel != 100 || r50.OutdoorChannel != 100 || r50.ChannelSource != SourceAp {
+		t.Errorf("5GHz channels = %d/%d from %s; want the ap override on both", r50.Channel, r50.OutdoorChannel, r50.ChannelSource)
+	}
+	if r24 := radios[0]; r24.Channel != 6 || r24.OutdoorChannel != 0 {
+		t.Errorf("2.4GHz channels = %d/%d", r24.Channel, r24.OutdoorChannel)
+	}
+}
@@ -0,0 +1,584 @@
+package ruckus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DesiredState the Site Configuration a Controller is Reconciled to
+type DesiredState struct {
+	Zones []DesiredZone `json:"zones" yaml:"zones"`
+}
+
+// DesiredZone a Zone with its AP Groups, WLANs and APs
+type DesiredZone struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	CountryCode string `json:"countryCode" yaml:"countryCode"`
+	// required to create the Zone
+	Login  *RksApLogin      `json:"login,omitempty" yaml:"login"`
+	Groups []DesiredApGroup `json:"groups" yaml:"groups"`
+	Wlans  []DesiredWlan    `json:"wlans" yaml:"wlans"`
+	Aps    []DesiredAp      `json:"aps" yaml:"aps"`
+}
+
+// DesiredApGroup an AP Group of a DesiredZone
+type DesiredApGroup struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// DesiredWlan a WLAN of a DesiredZone
+type DesiredWlan struct {
+	Name        string             `json:"name" yaml:"name"`
+	SSID        string             `json:"ssid" yaml:"ssid"`
+	Description string             `json:"description" yaml:"description"`
+	Encryption  *RksWlanEncryption `json:"encryption,omitempty" yaml:"encryption"`
+	VlanID      int                `json:"vlanId" yaml:"vlanId"`
+}
+
+// DesiredAp the Name and Placement of an AP within its DesiredZone
+type DesiredAp struct {
+	MacAddr string `json:"mac" yaml:"mac"`
+	Name    string `json:"name" yaml:"name"`
+	// the AP Group Name; empty leaves the AP Group unchanged
+	Group string `json:"group" yaml:"group"`
+}
+
+// ReconcileOptions controls how Reconcile converges the Controller
+type ReconcileOptions struct {
+	// compute the Plan without applying it
+	DryRun bool
+	// delete Zones, AP Groups and WLANs absent from the DesiredState
+	Prune bool
+}
+
+// ChangeAction what a Change does to a Resource
+type ChangeAction string
+
+// Change Actions
+const (
+	ActionCreate ChangeAction = "create"
+	ActionUpdate ChangeAction = "update"
+	ActionDelete ChangeAction = "delete"
+)
+
+// ResourceKind the kind of Resource a Change applies to
+type ResourceKind string
+
+// Resource Kinds managed by Reconcile
+const (
+	ResourceZone    ResourceKind = "zone"
+	ResourceApGroup ResourceKind = "apGroup"
+	ResourceWlan    ResourceKind = "wlan"
+	ResourceAp      ResourceKind = "ap"
+)
+
+// Change a single step of a Plan along with its outcome once applied
+type Change struct {
+	Action ChangeAction `json:"action"`
+	Kind   ResourceKind `json:"kind"`
+	Zone   string       `json:"zone"`
+	Name   string       `json:"name"`
+	// the ID of the Resource (known before apply for Updates|Deletes)
+	ID      string      `json:"id,omitempty"`
+	Diffs   []FieldDiff `json:"diffs,omitempty"`
+	Applied bool        `json:"applied"`
+	Error   string      `json:"error,omitempty"`
+
+	stage int
+	apply func(st *applyState) (string, error)
+}
+
+// Plan the Changes converging a Controller to a DesiredState
+type Plan struct {
+	Changes  []Change `json:"changes"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Stages Changes are applied in; Resources are created parents first
+// and deleted children first
+const (
+	stageCreateZone = iota
+	stageUpdateZone
+	stageCreateGroup
+	stageUpdateGroup
+	stageCreateWlan
+	stageUpdateWlan
+	stageUpdateAp
+	stageDeleteWlan
+	stageDeleteGroup
+	stageDeleteZone
+)
+
+// applyState the IDs of Zones and AP Groups including those created while applying
+type applyState struct {
+	c      *Client
+	zones  map[string]string
+	groups map[string]string
+}
+
+func groupKey(zone, group string) string {
+	return zone + "/" + group
+}
+
+func (st *applyState) zoneID(zone string) (string, error) {
+	id := st.zones[zone]
+	if id == "" {
+		return "", fmt.Errorf("zone %s does not exist", zone)
+	}
+	return id, nil
+}
+
+// Reconcile compares desired with the live Controller and computes the Plan
+// converging them; unless o.DryRun the Plan is applied in dependency order
+// A failing Change is recorded on the Plan and does not stop the others
+func (c *Client) Reconcile(ctx context.Context, desired DesiredState, o ReconcileOptions) (*Plan, error) {
+	st := &applyState{
+		c:      c,
+		zones:  make(map[string]string),
+		groups: make(map[string]string),
+	}
+	plan, err := c.plan(ctx, desired, o, st)
+	if err != nil || o.DryRun {
+		return plan, err
+	}
+	failed := 0
+	for i := range plan.Changes {
+		if err := ctx.Err(); err != nil {
+			return plan, err
+		}
+		ch := &plan.Changes[i]
+		id, err := ch.apply(st)
+		if err != nil {
+			ch.Error = err.Error()
+			failed++
+			continue
+		}
+		if id != "" {
+			ch.ID = id
+		}
+		ch.Applied = true
+	}
+	if failed > 0 {
+		return plan, fmt.Errorf("%d of %d changes failed", failed, len(plan.Changes))
+	}
+	return plan, nil
+}
+
+// plan reads the live State of the Controller computing the Changes to desired
+func (c *Client) plan(ctx context.Context, desired DesiredState, o ReconcileOptions, st *applyState) (*Plan, error) {
+	plan := &Plan{}
+	zones, err := c.GetAllZones(RksOptions{})
+	if err != nil {
+		return plan, err
+	}
+	for _, z := range zones {
+		st.zones[z.Name] = z.ID
+	}
+	aps, err := c.GetAPs(RksOptions{})
+	if err != nil {
+		return plan, err
+	}
+	liveAps := make(map[string]RksAp, len(aps))
+	for _, ap := range aps {
+		liveAps[strings.ToUpper(ap.MacAddr)] = ap
+	}
+
+	wanted := make(map[string]bool)
+	for _, dz := range desired.Zones {
+		if err := ctx.Err(); err != nil {
+			return plan, err
+		}
+		wanted[dz.Name] = true
+		zoneID := st.zones[dz.Name]
+		if zoneID == "" {
+			plan.add(createZone(dz))
+		} else {
+			if err := c.planZone(dz, zoneID, plan); err != nil {
+				return plan, err
+			}
+		}
+		if err := c.planGroups(dz, zoneID, o, st, plan); err != nil {
+			return plan, err
+		}
+		if err := c.planWlans(dz, zoneID, o, plan); err != nil {
+			return plan, err
+		}
+		planAps(dz, liveAps, plan)
+	}
+	if o.Prune {
+		for _, z := range zones {
+			if !wanted[z.Name] {
+				plan.add(deleteZone(z))
+			}
+		}
+	}
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].stage < plan.Changes[j].stage
+	})
+	return plan, nil
+}
+
+func (p *Plan) add(ch Change) {
+	p.Changes = append(p.Changes, ch)
+}
+
+func createZone(dz DesiredZone) Change {
+	req := RksZoneReq{
+		Name:        dz.Name,
+		Description: dz.Description,
+		CountryCode: dz.CountryCode,
+		Login:       dz.Login,
+	}
+	return Change{
+		Action: ActionCreate, Kind: ResourceZone, Zone: dz.Name, Name: dz.Name,
+		stage: stageCreateZone,
+		apply: func(st *applyState) (string, error) {
+			id, err := st.c.CreateZone(req)
+			if err == nil {
+				st.zones[dz.Name] = id
+			}
+			return id, err
+		},
+	}
+}
+
+func deleteZone(z RksObject) Change {
+	return Change{
+		Action: ActionDelete, Kind: ResourceZone, Zone: z.Name, Name: z.Name, ID: z.ID,
+		stage: stageDeleteZone,
+		apply: func(st *applyState) (string, error) {
+			return "", st.c.DeleteZone(z.ID)
+		},
+	}
+}
+
+func (c *Client) planZone(dz DesiredZone, zoneID string, plan *Plan) error {
+	live, err := c.GetZone(zoneID)
+	if err != nil {
+		return err
+	}
+	var diffs []FieldDiff
+	if dz.Description != "" {
+		diffs = diffField(diffs, "description", live.Description, dz.Description)
+	}
+	if dz.CountryCode != "" {
+		diffs = diffField(diffs, "countryCode", live.CountryCode, dz.CountryCode)
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	req := RksZoneReq{Description: dz.Description, CountryCode: dz.CountryCode}
+	plan.add(Change{
+		Action: ActionUpdate, Kind: ResourceZone, Zone: dz.Name, Name: dz.Name, ID: zoneID,
+		Diffs: diffs,
+		stage: stageUpdateZone,
+		apply: func(st *applyState) (string, error) {
+			return "", st.c.UpdateZone(zoneID, req)
+		},
+	})
+	return nil
+}
+
+func (c *Client) planGroups(dz DesiredZone, zoneID string, o ReconcileOptions, st *applyState, plan *Plan) error {
+	live := make(map[string]RksObject)
+	if zoneID != "" {
+		grps, err := c.GetAllApGroups(zoneID)
+		if err != nil {
+			return err
+		}
+		for _, g := range grps {
+			live[g.Name] = g
+			st.groups[groupKey(dz.Name, g.Name)] = g.ID
+		}
+	}
+	wanted := make(map[string]bool)
+	for _, dg := range dz.Groups {
+		dg := dg
+		wanted[dg.Name] = true
+		lg, ok := live[dg.Name]
+		if !ok {
+			plan.add(Change{
+				Action: ActionCreate, Kind: ResourceApGroup, Zone: dz.Name, Name: dg.Name,
+				stage: stageCreateGroup,
+				apply: func(st *applyState) (string, error) {
+					zid, err := st.zoneID(dz.Name)
+					if err != nil {
+						return "", err
+					}
+					id, err := st.c.CreateApGroup(zid, RksApGroup{Name: dg.Name, Description: dg.Description})
+					if err == nil {
+						st.groups[groupKey(dz.Name, dg.Name)] = id
+					}
+					return id, err
+				},
+			})
+			continue
+		}
+		if dg.Description == "" {
+			continue
+		}
+		grp, err := c.GetApGroup(zoneID, lg.ID)
+		if err != nil {
+			return err
+		}
+		diffs := diffField(nil, "description", grp.Description, dg.Description)
+		if len(diffs) == 0 {
+			continue
+		}
+		plan.add(Change{
+			Action: ActionUpdate, Kind: ResourceApGroup, Zone: dz.Name, Name: dg.Name, ID: lg.ID,
+			Diffs: diffs,
+			stage: stageUpdateGroup,
+			apply: func(st *applyState) (string, error) {
+				return "", st.c.UpdateApGroup(zoneID, lg.ID, RksApGroup{Description: dg.Description})
+			},
+		})
+	}
+	if !o.Prune {
+		return nil
+	}
+	for name, lg := range live {
+		// the default AP Group of a Zone cannot be removed
+		if wanted[name] || strings.EqualFold(name, "default") {
+			continue
+		}
+		lg := lg
+		plan.add(Change{
+			Action: ActionDelete, Kind: ResourceApGroup, Zone: dz.Name, Name: name, ID: lg.ID,
+			stage: stageDeleteGroup,
+			apply: func(st *applyState) (string, error) {
+				return "", st.c.DeleteApGroup(zoneID, lg.ID)
+			},
+		})
+	}
+	return nil
+}
+
+func (dw DesiredWlan) config() RksWlanConfig {
+	w := RksWlanConfig{
+		Name:        dw.Name,
+		SSID:        dw.SSID,
+		Description: dw.Description,
+		Encryption:  dw.Encryption,
+	}
+	if dw.VlanID != 0 {
+		w.Vlan = &RksWlanVlan{AccessVlan: dw.VlanID}
+	}
+	return w
+}
+
+func (c *Client) planWlans(dz DesiredZone, zoneID string, o ReconcileOptions, plan *Plan) error {
+	live := make(map[string]RksObject)
+	if zoneID != "" {
+		wlans, err := c.GetZoneWlans(RksOptions{}, zoneID)
+		if err != nil {
+			return err
+		}
+		for _, w := range wlans {
+			live[w.Name] = w
+		}
+	}
+	wanted := make(map[string]bool)
+	for _, dw := range dz.Wlans {
+		dw := dw
+		wanted[dw.Name] = true
+		lw, ok := live[dw.Name]
+		if !ok {
+			plan.add(Change{
+				Action: ActionCreate, Kind: ResourceWlan, Zone: dz.Name, Name: dw.Name,
+				stage: stageCreateWlan,
+				apply: func(st *applyState) (string, error) {
+					zid, err := st.zoneID(dz.Name)
+					if err != nil {
+						return "", err
+					}
+					return st.c.CreateWlan(zid, dw.config())
+				},
+			})
+			continue
+		}
+		cur, err := c.GetWlan(zoneID, lw.ID)
+		if err != nil {
+			return err
+		}
+		var diffs []FieldDiff
+		diffs = diffField(diffs, "ssid", cur.SSID, dw.SSID)
+		if dw.Description != "" {
+			diffs = diffField(diffs, "description", cur.Description, dw.Description)
+		}
+		if dw.Encryption != nil {
+			var ce RksWlanEncryption
+			if cur.Encryption != nil {
+				ce = *cur.Encryption
+			}
+			diffs = diffField(diffs, "encryption.method", ce.Method, dw.Encryption.Method)
+			diffs = diffField(diffs, "encryption.algorithm", ce.Algorithm, dw.Encryption.Algorithm)
+			// never echo passphrases into the Plan
+			if isMasked(ce.Passphrase) {
+				// a passphrase the Controller masks cannot be compared
+				if dw.Encryption.Passphrase != "" {
+					plan.Warnings = append(plan.Warnings, fmt.Sprintf(
+						"passphrase of wlan %s/%s is masked by the controller; whether it changed is unknown",
+						dz.Name, dw.Name,
+					))
+				}
+			} else if ce.Passphrase != dw.Encryption.Passphrase {
+				diffs = append(diffs, FieldDiff{Path: "encryption.passphrase", Old: "(sensitive)", New: "(sensitive)"})
+			}
+		}
+		if dw.VlanID != 0 {
+			var cv int
+			if cur.Vlan != nil {
+				cv = cur.Vlan.AccessVlan
+			}
+			diffs = diffField(diffs, "vlan.accessVlan", cv, dw.VlanID)
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+		plan.add(Change{
+			Action: ActionUpdate, Kind: ResourceWlan, Zone: dz.Name, Name: dw.Name, ID: lw.ID,
+			Diffs: diffs,
+			stage: stageUpdateWlan,
+			apply: func(st *applyState) (string, error) {
+				return "", st.c.UpdateWlan(zoneID, lw.ID, dw.config())
+			},
+		})
+	}
+	if !o.Prune {
+		return nil
+	}
+	for name, lw := range live {
+		if wanted[name] {
+			continue
+		}
+		lw := lw
+		plan.add(Change{
+			Action: ActionDelete, Kind: ResourceWlan, Zone: dz.Name, Name: name, ID: lw.ID,
+			stage: stageDeleteWlan,
+			apply: func(st *applyState) (string, error) {
+				return "", st.c.DeleteWlan(zoneID, lw.ID)
+			},
+		})
+	}
+	return nil
+}
+
+// isMasked reports whether a secret was returned masked (ie "********")
+func isMasked(s string) bool {
+	return s != "" && strings.Trim(s, "*") == ""
+}
+
+func planAps(dz DesiredZone, liveAps map[string]RksAp, plan *Plan) {
+	for _, da := range dz.Aps {
+		da := da
+		ap, ok := liveAps[strings.ToUpper(da.MacAddr)]
+		if !ok {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf(
+				"ap %s (%s) is not known to the controller", da.MacAddr, da.Name,
+			))
+			continue
+		}
+		var diffs []FieldDiff
+		if da.Name != "" {
+			diffs = diffField(diffs, "name", ap.ApName, da.Name)
+		}
+		diffs = diffField(diffs, "zone", ap.ZoneName, dz.Name)
+		if da.Group != "" {
+			diffs = diffField(diffs, "group", ap.GroupName, da.Group)
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+		plan.add(Change{
+			Action: ActionUpdate, Kind: ResourceAp, Zone: dz.Name, Name: da.MacAddr, ID: ap.MacAddr,
+			Diffs: diffs,
+			stage: stageUpdateAp,
+			apply: func(st *applyState) (string, error) {
+				req := RksApReq{Name: da.Name}
+				if ap.ZoneName != dz.Name || (da.Group != "" && ap.GroupName != da.Group) {
+					zid, err := st.zoneID(dz.Name)
+					if err != nil {
+						return "", err
+					}
+					req.ZoneID = zid
+					if da.Group != "" {
+						gid := st.groups[groupKey(dz.Name, da.Group)]
+						if gid == "" {
+							return "", fmt.Errorf("ap group %s does not exist in zone %s", da.Group, dz.Name)
+						}
+						req.GroupID = gid
+					}
+				}
+				return "", st.c.UpdateAp(ap.MacAddr, req)
+			},
+		})
+	}
+}
+
+func diffField(diffs []FieldDiff, path string, old, new interface{}) []FieldDiff {
+	if old == new {
+		return diffs
+	}
+	return append(diffs, FieldDiff{Path: path, Old: old, New: new})
+}
+
+// Counts returns the number of Changes creating, updating and deleting Resources
+func (p *Plan) Counts() (create, update, delete int) {
+	for _, ch := range p.Changes {
+		switch ch.Action {
+		case ActionCreate:
+			create++
+		case ActionUpdate:
+			update++
+		case ActionDelete:
+			delete++
+		}
+	}
+	return create, update, delete
+}
+
+// String renders the Plan for humans in the order it is applied
+func (p *Plan) String() string {
+	var sb strings.Builder
+	symbols := map[ChangeAction]string{
+		ActionCreate: "+",
+		ActionUpdate: "~",
+		ActionDelete: "-",
+	}
+	for _, ch := range p.Changes {
+		name := ch.Name
+		if ch.Kind != ResourceZone {
+			name = ch.Zone + "/" + ch.Name
+		}
+		fmt.Fprintf(&sb, "%s %s %q", symbols[ch.Action], ch.Kind, name)
+		if ch.Error != "" {
+			fmt.Fprintf(&sb, " (failed: %s)", ch.Error)
+		}
+		sb.WriteString("\n")
+		for _, d := range ch.Diffs {
+			fmt.Fprintf(&sb, "    %s: %v => %v\n", d.Path, quoteIfString(d.Old), quoteIfString(d.New))
+		}
+	}
+	for _, w := range p.Warnings {
+		fmt.Fprintf(&sb, "! %s\n", w)
+	}
+	create, update, del := p.Counts()
+	if create+update+del == 0 {
+		sb.WriteString("No changes. The controller matches the desired state.\n")
+	} else {
+		fmt.Fprintf(&sb, "Plan: %d to create, %d to update, %d to delete.\n", create, update, del)
+	}
+	return sb.String()
+}
+
+func quoteIfString(v interface{}) interface{} {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return v
+}
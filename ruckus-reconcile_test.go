@@ -0,0 +1,180 @@
+package ruckus
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// onCampus serves a Controller with the Zones campus (to converge) and old
+// (to prune); campus has 3 AP Groups over 2 pages, 3 WLANs and 1 AP
+func onCampus(f *fakeController) {
+	f.on("GET /rkszones", `{"hasMore":false,"list":[{"id":"z1","name":"campus"},{"id":"z2","name":"old"}]}`)
+	f.on("GET /rkszones/z1", `{"id":"z1","name":"campus","description":"main","countryCode":"US"}`)
+	f.on("GET /rkszones/z1/apgroups",
+		`{"hasMore":true,"firstIndex":0,"list":[{"id":"g0","name":"default"},{"id":"g1","name":"lobby"}]}`,
+		`{"hasMore":false,"firstIndex":2,"list":[{"id":"g2","name":"stale"}]}`,
+	)
+	f.on("GET /rkszones/z1/apgroups/g1", `{"id":"g1","name":"lobby","description":"old"}`)
+	f.on("GET /rkszones/z1/wlans", `{"list":[{"id":"w1","name":"staff"},{"id":"w2","name":"guest"},{"id":"w3","name":"legacy"}]}`)
+	f.on("GET /rkszones/z1/wlans/w1", `{"id":"w1","name":"staff","ssid":"staff","encryption":{"method":"WPA2","algorithm":"AES","passphrase":"********"}}`)
+	f.on("GET /rkszones/z1/wlans/w2", `{"id":"w2","name":"guest","ssid":"guest","vlan":{"accessVlan":10}}`)
+	f.on("POST /query/ap", `{"list":[{"apMac":"AA:BB:CC:00:00:01","deviceName":"ap1","zoneName":"campus","apGroupName":"default"}]}`)
+}
+
+var desiredCampus = DesiredState{Zones: []DesiredZone{
+	{
+		Name: "campus", Description: "main", CountryCode: "US",
+		Groups: []DesiredApGroup{{Name: "lobby", Description: "entrance"}, {Name: "labs"}},
+		Wlans: []DesiredWlan{
+			{Name: "staff", SSID: "staff", Encryption: &RksWlanEncryption{Method: "WPA2", Algorithm: "AES", Passphrase: "secret"}},
+			{Name: "guest", SSID: "guest", VlanID: 20},
+		},
+		Aps: []DesiredAp{
+			{MacAddr: "aa:bb:cc:00:00:01", Name: "lobby-1", Group: "labs"},
+			{MacAddr: "aa:bb:cc:00:00:99", Name: "missing"},
+		},
+	},
+	{Name: "branch", CountryCode: "US", Groups: []DesiredApGroup{{Name: "ops"}}},
+}}
+
+type planStep struct {
+	action ChangeAction
+	kind   ResourceKind
+	name   string
+}
+
+func steps(p *Plan) []planStep {
+	var s []planStep
+	for _, ch := range p.Changes {
+		s = append(s, planStep{ch.Action, ch.Kind, ch.Zone + "/" + ch.Name})
+	}
+	return s
+}
+
+func TestReconcilePlan(t *testing.T) {
+	f, c := newFakeController(t)
+	onCampus(f)
+
+	plan, err := c.Reconcile(context.Background(), desiredCampus, ReconcileOptions{DryRun: true, Prune: true})
+	if err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+	want := []planStep{
+		{ActionCreate, ResourceZone, "branch/branch"},
+		{ActionCreate, ResourceApGroup, "campus/labs"},
+		{ActionCreate, ResourceApGroup, "branch/ops"},
+		{ActionUpdate, ResourceApGroup, "campus/lobby"},
+		{ActionUpdate, ResourceWlan, "campus/guest"},
+		{ActionUpdate, ResourceAp, "campus/aa:bb:cc:00:00:01"},
+		{ActionDelete, ResourceWlan, "campus/legacy"},
+		{ActionDelete, ResourceApGroup, "campus/stale"},
+		{ActionDelete, ResourceZone, "old/old"},
+	}
+	if got := steps(plan); !reflect.DeepEqual(got, want) {
+		t.Errorf("plan =\n%v\nwant\n%v", got, want)
+	}
+	if len(plan.Warnings) != 2 ||
+		!strings.Contains(plan.Warnings[0], "passphrase of wlan campus/staff") ||
+		!strings.Contains(plan.Warnings[1], "aa:bb:cc:00:00:99") {
+		t.Errorf("warnings = %q; want the masked passphrase and the unknown ap", plan.Warnings)
+	}
+	for _, r := range f.received("") {
+		if r.Method != "GET" && !(r.Method == "POST" && r.Path == "/query/ap") {
+			t.Errorf("a dry run sent %s %s", r.Method, r.Path)
+		}
+	}
+}
+
+func TestReconcilePlanInSync(t *testing.T) {
+	f, c := newFakeController(t)
+	onCampus(f)
+	desired := DesiredState{Zones: []DesiredZone{{
+		Name: "campus", Description: "main",
+		Groups: []DesiredApGroup{{Name: "lobby", Description: "old"}},
+		Wlans:  []DesiredWlan{{Name: "guest", SSID: "guest", VlanID: 10}},
+		Aps:    []DesiredAp{{MacAddr: "AA:BB:CC:00:00:01", Name: "ap1", Group: "default"}},
+	}}}
+
+	plan, err := c.Reconcile(context.Background(), desired, ReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+	if len(plan.Changes) != 0 || len(plan.Warnings) != 0 {
+		t.Errorf("plan = %v; want no changes", plan)
+	}
+	if !strings.Contains(plan.String(), "No changes") {
+		t.Errorf("String() = %q", plan.String())
+	}
+}
+
+func TestReconcileApply(t *testing.T) {
+	f, c := newFakeController(t)
+	onCampus(f)
+	f.on("POST /rkszones", `{"id":"z3"}`)
+	f.on("POST /rkszones/z1/apgroups", `{"id":"g9"}`)
+	f.on("POST /rkszones/z3/apgroups", `{"id":"g10"}`)
+	f.on("PATCH /rkszones/z1/apgroups/g1", `{}`)
+	f.on("PATCH /rkszones/z1/wlans/w2", `{}`)
+	f.on("PATCH /aps/AA:BB:CC:00:00:01", `{}`)
+	f.on("DELETE /rkszones/z1/wlans/w3", `{}`)
+	f.on("DELETE /rkszones/z1/apgroups/g2", `{}`)
+	// the old Zone cannot be removed
+	f.onStatus("DELETE /rkszones/z2", 422, `{"message":"zone has aps"}`)
+
+	plan, err := c.Reconcile(context.Background(), desiredCampus, ReconcileOptions{Prune: true})
+	if err == nil || err.Error() != "1 of 9 changes failed" {
+		t.Errorf("Reconcile() = %v; want 1 of 9 changes failed", err)
+	}
+	for _, ch := range plan.Changes {
+		failed := ch.Kind == ResourceZone && ch.Action == ActionDelete
+		if ch.Applied == failed || (ch.Error != "") != failed {
+			t.Errorf("%s %s %s: applied %v error %q", ch.Action, ch.Kind, ch.Name, ch.Applied, ch.Error)
+		}
+	}
+	if ch := plan.Changes[0]; ch.ID != "z3" {
+		t.Errorf("created zone ID = %q; want z3", ch.ID)
+	}
+
+	// the AP Group of the new Zone is created in it
+	if reqs := f.received("POST /rkszones/z3/apgroups"); len(reqs) != 1 || !strings.Contains(reqs[0].Body, `"name":"ops"`) {
+		t.Errorf("POST /rkszones/z3/apgroups = %v", reqs)
+	}
+	// the AP moves to the AP Group created before it
+	reqs := f.received("PATCH /aps/AA:BB:CC:00:00:01")
+	if len(reqs) != 1 {
+		t.Fatalf("PATCH /aps = %v; want 1 request", reqs)
+	}
+	var ap RksApReq
+	if err := json.Unmarshal([]byte(reqs[0].Body), &ap); err != nil {
+		t.Fatal(err)
+	}
+	if want := (RksApReq{Name: "lobby-1", ZoneID: "z1", GroupID: "g9"}); ap != want {
+		t.Errorf("PATCH /aps = %+v; want %+v", ap, want)
+	}
+	// the WLAN with the masked passphrase is left alone
+	if reqs := f.received("PATCH /rkszones/z1/wlans/w1"); len(reqs) != 0 {
+		t.Errorf("PATCH /rkszones/z1/wlans/w1 = %v; want none", reqs)
+	}
+	if reqs := f.received("PATCH /rkszones/z1/wlans/w2"); len(reqs) != 1 || !strings.Contains(reqs[0].Body, `"accessVlan":20`) {
+		t.Errorf("PATCH /rkszones/z1/wlans/w2 = %v", reqs)
+	}
+}
+
+func TestReconcileCancelled(t *testing.T) {
+	f, c := newFakeController(t)
+	onCampus(f)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Reconcile(ctx, desiredCampus, ReconcileOptions{}); err != context.Canceled {
+		t.Errorf("Reconcile() = %v; want context.Canceled", err)
+	}
+	for _, r := range f.received("") {
+		if r.Method != "GET" && !(r.Method == "POST" && r.Path == "/query/ap") {
+			t.Errorf("a cancelled reconcile sent %s %s", r.Method, r.Path)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package ruckus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries failed requests
+type RetryPolicy struct {
+	// the max number of attempts per request (including the first).
+	// 1 or less disables retries
+	MaxAttempts int
+	// the delay before the first retry; doubled on every attempt
+	BaseDelay time.Duration
+	// the upper bound of the delay between attempts
+	MaxDelay time.Duration
+	// retry non-idempotent requests (RebootAp, POST creations) as well.
+	// Default false
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is the RetryPolicy a Client is created with
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// backoff returns an exponential delay with full jitter for the given attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	max := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.MaxDelay > 0 && max > float64(p.MaxDelay) {
+		max = float64(p.MaxDelay)
+	}
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max) + 1))
+}
+
+// delay how long to wait before retrying a request that got res (nil on
+// a connection error) on the given attempt: the Retry-After the Controller
+// asks for, up to MaxDelay, or else the backoff
+func (p RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if d, ok := retryAfter(res); ok {
+			// the Controller may not stall us past our own bound
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				d = p.MaxDelay
+			}
+			return d
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// RateLimiter is a token bucket shared by every goroutine using it
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing perSecond requests
+// on average with bursts of up to burst requests
+// a perSecond of 0 or less does not limit requests
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a request may be issued or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return ctx.Err()
+	}
+	delay := l.reserve()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// Give back the reservation we will not use
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token returning how long to wait before it may be used
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// Reserve a token now; a negative balance is the time we owe
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// do issues req retrying on failure when its Method is idempotent
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return c.doRetry(req, isIdempotent(req.Method))
+}
+
+// doRetry issues req honoring the Client RateLimiter and RetryPolicy
+// idempotent requests are retried; others only if the policy opts in
+func (c *Client) doRetry(req *http.Request, idempotent bool) (*http.Response, error) {
+	p := c.Retry
+	attempts := p.MaxAttempts
+	if attempts < 1 || (!idempotent && !p.RetryNonIdempotent) {
+		attempts = 1
+	}
+	// A Body we cannot rewind can only be sent once
+	if req.Body != nil && req.GetBody == nil {
+		attempts = 1
+	}
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		res, err := c.http.Do(req)
+		if attempt >= attempts || !shouldRetry(res, err) {
+			return res, err
+		}
+		delay := p.delay(attempt, res)
+		if res != nil {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether the outcome of a request is worth another attempt
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNABORTED) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, syscall.EPIPE) ||
+			errors.Is(err, io.EOF) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After Header (delay-seconds or HTTP-date)
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -0,0 +1,226 @@
+package ruckus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeTransport answers every request with the next of its outcomes
+// (the last one repeated) counting the attempts
+type fakeTransport struct {
+	mu       sync.Mutex
+	outcomes []fakeOutcome
+	bodies   []string
+}
+
+// fakeOutcome a response of status (with Retry-After and body when set) or err
+type fakeOutcome struct {
+	status     int
+	retryAfter string
+	body       string
+	err        error
+}
+
+func (f *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var body string
+	if req.Body != nil {
+		b, _ := ioutil.ReadAll(req.Body)
+		body = string(b)
+	}
+	f.bodies = append(f.bodies, body)
+	o := f.outcomes[len(f.outcomes)-1]
+	if n := len(f.bodies) - 1; n < len(f.outcomes) {
+		o = f.outcomes[n]
+	}
+	if o.err != nil {
+		return nil, o.err
+	}
+	if o.body == "" {
+		o.body = "{}"
+	}
+	res := &http.Response{
+		StatusCode: o.status,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(strings.NewReader(o.body)),
+		Request:    req,
+	}
+	if o.retryAfter != "" {
+		res.Header.Set("Retry-After", o.retryAfter)
+	}
+	return res, nil
+}
+
+func (f *fakeTransport) attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.bodies)
+}
+
+// newRetryClient a Client retrying without delay over outcomes
+func newRetryClient(outcomes ...fakeOutcome) (*Client, *fakeTransport) {
+	tr := &fakeTransport{outcomes: outcomes}
+	c := New("9_0", "localhost", "admin", "admin", true)
+	c.http.Transport = tr
+	c.Retry = RetryPolicy{MaxAttempts: 3}
+	return c, tr
+}
+
+func TestDoRetry(t *testing.T) {
+	unavailable := fakeOutcome{status: http.StatusServiceUnavailable}
+	ok := fakeOutcome{status: http.StatusOK}
+	reset := fakeOutcome{err: fmt.Errorf("read: %w", syscall.ECONNRESET)}
+	tests := []struct {
+		name     string
+		method   string
+		optIn    bool
+		outcomes []fakeOutcome
+		attempts int
+		status   int
+	}{
+		{"recovers", "GET", false, []fakeOutcome{unavailable, unavailable, ok}, 3, http.StatusOK},
+		{"gives up", "GET", false, []fakeOutcome{unavailable}, 3, http.StatusServiceUnavailable},
+		{"connection reset", "GET", false, []fakeOutcome{reset, ok}, 2, http.StatusOK},
+		{"throttled", "DELETE", false, []fakeOutcome{{status: http.StatusTooManyRequests}, ok}, 2, http.StatusOK},
+		{"client error", "GET", false, []fakeOutcome{{status: http.StatusBadRequest}, ok}, 1, http.StatusBadRequest},
+		{"server error", "GET", false, []fakeOutcome{{status: http.StatusInternalServerError}, ok}, 1, http.StatusInternalServerError},
+		{"post", "POST", false, []fakeOutcome{unavailable, ok}, 1, http.StatusServiceUnavailable},
+		{"post opted in", "POST", true, []fakeOutcome{unavailable, ok}, 2, http.StatusOK},
+	}
+	for _, tt := range tests {
+		c, tr := newRetryClient(tt.outcomes...)
+		c.Retry.RetryNonIdempotent = tt.optIn
+		req, _ := http.NewRequest(tt.method, "https://sz/api", bytes.NewReader([]byte(`{"name":"x"}`)))
+		res, err := c.do(req)
+		if tr.attempts() != tt.attempts {
+			t.Errorf("%s: %d attempts; want %d", tt.name, tr.attempts(), tt.attempts)
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if res.StatusCode != tt.status {
+			t.Errorf("%s: status %d; want %d", tt.name, res.StatusCode, tt.status)
+		}
+		for i, b := range tr.bodies {
+			if b != `{"name":"x"}` {
+				t.Errorf("%s: attempt %d sent %q", tt.name, i+1, b)
+			}
+		}
+	}
+}
+
+func TestDoRetryNonIdempotentCall(t *testing.T) {
+	// RebootAp is a GET the Controller must not see twice
+	c, tr := newRetryClient(fakeOutcome{status: http.StatusServiceUnavailable}, fakeOutcome{status: http.StatusOK})
+	req, _ := http.NewRequest("GET", "https://sz/api/reboot", nil)
+	if _, err := c.doRetry(req, false); err != nil {
+		t.Fatal(err)
+	}
+	if tr.attempts() != 1 {
+		t.Errorf("%d attempts; want 1", tr.attempts())
+	}
+}
+
+func TestDoRetryStopsWithContext(t *testing.T) {
+	c, tr := newRetryClient(fakeOutcome{status: http.StatusServiceUnavailable, retryAfter: "3600"})
+	c.Retry.MaxDelay = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequest("GET", "https://sz/api", nil)
+	if _, err := c.do(req.WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Errorf("do() = %v; want the context deadline", err)
+	}
+	if tr.attempts() != 1 {
+		t.Errorf("%d attempts; want 1", tr.attempts())
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	header := func(v string) *http.Response {
+		res := &http.Response{Header: make(http.Header)}
+		if v != "" {
+			res.Header.Set("Retry-After", v)
+		}
+		return res
+	}
+	p := RetryPolicy{MaxDelay: 30 * time.Second}
+	tests := []struct {
+		name string
+		res  *http.Response
+		want time.Duration
+	}{
+		{"seconds", header("5"), 5 * time.Second},
+		{"capped", header("3600"), 30 * time.Second},
+		{"past date", header("Mon, 02 Jan 2006 15:04:05 GMT"), 0},
+		{"invalid", header("soon"), 0},
+		{"no header", header(""), 0},
+		{"connection error", nil, 0},
+	}
+	for _, tt := range tests {
+		if got := p.delay(1, tt.res); got != tt.want {
+			t.Errorf("%s: delay = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		for i := 0; i < 100; i++ {
+			if d := p.backoff(attempt); d < 0 || d > max {
+				t.Fatalf("backoff(%d) = %v; want within [0, %v]", attempt, d, max)
+			}
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(2, 2)
+	l.last, l.now = now, func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("request %d within the burst delayed %v", i+1, d)
+		}
+	}
+	if d := l.reserve(); d != 500*time.Millisecond {
+		t.Errorf("request past the burst delayed %v; want 500ms", d)
+	}
+	// the token owed is repaid after 500ms; one more is available after 1s
+	now = now.Add(time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("request after a refill delayed %v", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); err != context.Canceled {
+		t.Errorf("Wait() = %v; want the context error", err)
+	}
+	// the cancelled reservation was given back
+	if l.tokens != 0 {
+		t.Errorf("tokens = %v; want 0", l.tokens)
+	}
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		l := NewRateLimiter(rate, 1)
+		for i := 0; i < 100; i++ {
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatalf("rate %v: Wait() = %v", rate, err)
+			}
+		}
+	}
+}
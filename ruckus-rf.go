@@ -0,0 +1,290 @@
+package ruckus
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RadioBand a Radio of an AP
+type RadioBand string
+
+// the Radios of an AP
+const (
+	Band24 RadioBand = "2.4GHz"
+	Band50 RadioBand = "5GHz"
+)
+
+var radioBands = []RadioBand{Band24, Band50}
+
+// RksApRadio the Settings of a Radio an AP|AP Group overrides;
+// a field that is not set is inherited from the AP Group|Zone
+type RksApRadio struct {
+	// ie Full|-1dB..-10dB|Min
+	TxPower      string `json:"txPower,omitempty"`
+	ChannelWidth *int   `json:"channelWidth,omitempty"`
+	// 0 selects the Channel automatically
+	Channel      *int  `json:"channel,omitempty"`
+	ChannelRange []int `json:"channelRange,omitempty"`
+}
+
+// bandRadio the Settings of the band Radio among wifi24|wifi50
+func bandRadio(band RadioBand, wifi24, wifi50 *RksApRadio) *RksApRadio {
+	if band == Band24 {
+		return wifi24
+	}
+	return wifi50
+}
+
+// IsDfsChannel reports whether a 5GHz Channel requires DFS
+func IsDfsChannel(ch int) bool {
+	return ch >= 52 && ch <= 144
+}
+
+// ChannelUse the number of APs of a Zone operating on a Channel
+type ChannelUse struct {
+	Band    RadioBand
+	Channel int
+	// the Channel Width in MHz (0 when unknown)
+	Width int
+	Aps   int
+	Dfs   bool
+}
+
+// CoChannel the APs of an AP Group operating on the same Channel
+type CoChannel struct {
+	GroupID   string
+	GroupName string
+	Band      RadioBand
+	Channel   int
+	ApNames   []string
+}
+
+// the Kinds of RFFinding
+const (
+	// an AP on a DFS Channel while the Zone disables DFS Channels
+	FindingDfs = "DFS"
+	// an AP on a Channel outside the Channel Range of its Zone
+	FindingOutOfRange = "OUT_OF_RANGE"
+	// an AP|AP Group overriding the TX Power of its Zone
+	FindingTxPower = "TX_POWER"
+)
+
+// RFFinding an AP|AP Group departing from the Channel Plan of its Zone
+// ApMac is empty for a Finding on an AP Group
+type RFFinding struct {
+	Kind      string
+	Band      RadioBand
+	GroupName string
+	ApName    string
+	ApMac     string
+	Channel   int
+	Detail    string
+}
+
+// ZoneRFReport the Channel Plan of a Zone as operated by its APs
+type ZoneRFReport struct {
+	ZoneID            string
+	ZoneName          string
+	DfsChannelEnabled bool
+	Channels          []ChannelUse
+	CoChannel         []CoChannel
+	Findings          []RFFinding
+}
+
+// RFReport retrieves a Zone along with its AP Groups and APs and reports
+// the Channel distribution per Radio, the APs of an AP Group sharing a
+// Channel, the use of DFS Channels, the APs outside the Channel Range of
+// the Zone and the TX Power overrides
+func (c *Client) RFReport(zoneID string) (ZoneRFReport, error) {
+	zone, err := c.GetZone(zoneID)
+	if err != nil {
+		return ZoneRFReport{}, fmt.Errorf("failed to get zone: %v", err)
+	}
+	grps, err := c.GetAllApGroups(zoneID)
+	if err != nil {
+		return ZoneRFReport{}, fmt.Errorf("failed to get ap groups: %v", err)
+	}
+	var groups []RksApGroup
+	for _, g := range grps {
+		grp, err := c.GetApGroup(zoneID, g.ID)
+		if err != nil {
+			return ZoneRFReport{}, fmt.Errorf("failed to get ap group %s: %v", g.Name, err)
+		}
+		groups = append(groups, grp)
+	}
+	aps, err := c.GetZoneAps(zoneID)
+	if err != nil {
+		return ZoneRFReport{}, fmt.Errorf("failed to get aps: %v", err)
+	}
+	configs := make(map[string]RksApConfig, len(aps))
+	for _, ap := range aps {
+		cfg, err := c.GetApConfig(ap.MacAddr)
+		if err != nil {
+			return ZoneRFReport{}, fmt.Errorf("failed to get ap %s: %v", ap.MacAddr, err)
+		}
+		configs[ap.MacAddr] = cfg
+	}
+	return buildRFReport(zone, groups, aps, configs), nil
+}
+
+func buildRFReport(zone RksZone, groups []RksApGroup, aps []RksAp, configs map[string]RksApConfig) ZoneRFReport {
+	r := ZoneRFReport{
+		ZoneID:            zone.ID,
+		ZoneName:          zone.Name,
+		DfsChannelEnabled: zone.DfsChannelEnabled,
+	}
+	// an outdoor AP may use the outdoor range; the AP does not say which it is
+	allowed := map[RadioBand][]int{
+		Band24: zone.Wifi24.ChannelRange,
+		Band50: append(append([]int(nil), zone.Wifi50.IndoorChannelRange...), zone.Wifi50.OutdoorChannelRange...),
+	}
+	zonePower := map[RadioBand]string{Band24: zone.Wifi24.TxPower, Band50: zone.Wifi50.TxPower}
+
+	type useKey struct {
+		band      RadioBand
+		ch, width int
+	}
+	type coKey struct {
+		group string
+		band  RadioBand
+		ch    int
+	}
+	uses := make(map[useKey]int)
+	cochannel := make(map[coKey]*CoChannel)
+	for _, ap := range aps {
+		name := ap.ApName
+		if name == "" {
+			name = ap.MacAddr
+		}
+		radios := []struct {
+			band RadioBand
+			ch   int
+			desc string
+		}{{Band24, ap.Channel24, ap.Channel24Desc}, {Band50, ap.Channel50, ap.Channel50Desc}}
+		for _, rd := range radios {
+			if rd.ch == 0 {
+				continue
+			}
+			uses[useKey{rd.band, rd.ch, channelWidth(rd.desc)}]++
+			k := coKey{ap.GroupID, rd.band, rd.ch}
+			if cochannel[k] == nil {
+				cochannel[k] = &CoChannel{GroupID: ap.GroupID, GroupName: ap.GroupName, Band: rd.band, Channel: rd.ch}
+			}
+			cochannel[k].ApNames = append(cochannel[k].ApNames, name)
+
+			f := RFFinding{Band: rd.band, GroupName: ap.GroupName, ApName: name, ApMac: ap.MacAddr, Channel: rd.ch}
+			if rd.band == Band50 && IsDfsChannel(rd.ch) && !zone.DfsChannelEnabled {
+				f.Kind = FindingDfs
+				f.Detail = fmt.Sprintf("DFS channel %d while the zone disables DFS channels", rd.ch)
+				r.Findings = append(r.Findings, f)
+			}
+			if rng := allowed[rd.band]; len(rng) > 0 && !containsInt(rng, rd.ch) {
+				f.Kind = FindingOutOfRange
+				f.Detail = fmt.Sprintf("channel %d outside the zone range %s", rd.ch, joinInts(rng))
+				r.Findings = append(r.Findings, f)
+			}
+		}
+		cfg := configs[ap.MacAddr]
+		for _, band := range radioBands {
+			rd := bandRadio(band, cfg.Wifi24, cfg.Wifi50)
+			if rd == nil || rd.TxPower == "" || rd.TxPower == zonePower[band] {
+				continue
+			}
+			r.Findings = append(r.Findings, RFFinding{
+				Kind:      FindingTxPower,
+				Band:      band,
+				GroupName: ap.GroupName,
+				ApName:    name,
+				ApMac:     ap.MacAddr,
+				Detail:    fmt.Sprintf("ap txPower %s (zone %s)", rd.TxPower, zonePower[band]),
+			})
+		}
+	}
+	for _, g := range groups {
+		for _, band := range radioBands {
+			rd := bandRadio(band, g.Wifi24, g.Wifi50)
+			if rd == nil || rd.TxPower == "" || rd.TxPower == zonePower[band] {
+				continue
+			}
+			r.Findings = append(r.Findings, RFFinding{
+				Kind:      FindingTxPower,
+				Band:      band,
+				GroupName: g.Name,
+				Detail:    fmt.Sprintf("ap group txPower %s (zone %s)", rd.TxPower, zonePower[band]),
+			})
+		}
+	}
+
+	for k, n := range uses {
+		r.Channels = append(r.Channels, ChannelUse{
+			Band:    k.band,
+			Channel: k.ch,
+			Width:   k.width,
+			Aps:     n,
+			Dfs:     k.band == Band50 && IsDfsChannel(k.ch),
+		})
+	}
+	sort.Slice(r.Channels, func(i, j int) bool {
+		a, b := r.Channels[i], r.Channels[j]
+		if a.Band != b.Band {
+			return a.Band < b.Band
+		}
+		if a.Channel != b.Channel {
+			return a.Channel < b.Channel
+		}
+		return a.Width < b.Width
+	})
+	for _, cc := range cochannel {
+		if len(cc.ApNames) < 2 {
+			continue
+		}
+		sort.Strings(cc.ApNames)
+		r.CoChannel = append(r.CoChannel, *cc)
+	}
+	sort.Slice(r.CoChannel, func(i, j int) bool {
+		a, b := r.CoChannel[i], r.CoChannel[j]
+		if a.GroupName != b.GroupName {
+			return a.GroupName < b.GroupName
+		}
+		if a.Band != b.Band {
+			return a.Band < b.Band
+		}
+		return a.Channel < b.Channel
+	})
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		return r.Findings[i].Kind < r.Findings[j].Kind
+	})
+	return r
+}
+
+var channelWidthRe = regexp.MustCompile(`\((\d+)\s*MHz\)`)
+
+// channelWidth the Width in MHz of a Channel described as ie "36 (80MHz)"
+func channelWidth(desc string) int {
+	m := channelWidthRe.FindStringSubmatch(desc)
+	if m == nil {
+		return 0
+	}
+	w, _ := strconv.Atoi(m[1])
+	return w
+}
+
+func containsInt(list []int, v int) bool {
+	for _, i := range list {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+func joinInts(list []int) string {
+	s := make([]string, len(list))
+	for i, v := range list {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,139 @@
+package ruckus
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestBuildRFReport(t *testing.T) {
+	const zone = `{"id":"z1","name":"campus","dfsChannelEnabled":false,
+		"wifi24":{"txPower":"Full","channelRange":[1,6,11]},
+		"wifi50":{"txPower":"Full","indoorChannelRange":[36,40,44,48],"outdoorChannelRange":[149,153]}}`
+	tests := []struct {
+		name      string
+		zone      string
+		groups    []RksApGroup
+		aps       []RksAp
+		configs   map[string]RksApConfig
+		findings  []string
+		cochannel []string
+	}{
+		{
+			name: "in plan",
+			zone: zone,
+			aps: []RksAp{
+				{ApName: "a1", MacAddr: "m1", GroupID: "g1", GroupName: "east", Channel24: 1, Channel50: 36},
+				{ApName: "a2", MacAddr: "m2", GroupID: "g1", GroupName: "east", Channel24: 6, Channel50: 149},
+			},
+		},
+		{
+			name: "dfs channel while disabled",
+			zone: zone,
+			aps: []RksAp{
+				{ApName: "a1", MacAddr: "m1", GroupName: "east", Channel50: 100},
+			},
+			findings: []string{
+				"DFS 5GHz east a1 100",
+				"OUT_OF_RANGE 5GHz east a1 100",
+			},
+		},
+		{
+			name: "dfs channel while enabled",
+			zone: `{"dfsChannelEnabled":true,"wifi50":{"indoorChannelRange":[36,100]}}`,
+			aps: []RksAp{
+				{ApName: "a1", MacAddr: "m1", GroupName: "east", Channel50: 100},
+			},
+		},
+		{
+			name: "out of range",
+			zone: zone,
+			aps: []RksAp{
+				{ApName: "a1", MacAddr: "m1", GroupName: "east", Channel24: 3, Channel50: 40},
+				{MacAddr: "m2", GroupName: "east", Channel24: 11, Channel50: 165},
+			},
+			findings: []string{
+				"OUT_OF_RANGE 2.4GHz east a1 3",
+				"OUT_OF_RANGE 5GHz east m2 165",
+			},
+		},
+		{
+			name: "no zone range",
+			zone: `{"wifi24":{},"wifi50":{}}`,
+			aps: []RksAp{
+				{ApName: "a1", MacAddr: "m1", Channel24: 13, Channel50: 165},
+			},
+		},
+		{
+			name: "co-channel within a group only",
+			zone: zone,
+			aps: []RksAp{
+				{ApName: "a2", MacAddr: "m2", GroupID: "g1", GroupName: "east", Channel24: 6, Channel50: 36},
+				{ApName: "a1", MacAddr: "m1", GroupID: "g1", GroupName: "east", Channel24: 6, Channel50: 40},
+				{ApName: "a3", MacAddr: "m3", GroupID: "g2", GroupName: "west", Channel24: 6, Channel50: 36},
+			},
+			cochannel: []string{"east 2.4GHz 6 [a1 a2]"},
+		},
+		{
+			name: "tx power overrides",
+			zone: zone,
+			groups: []RksApGroup{
+				{ID: "g1", Name: "east", Wifi50: &RksApRadio{TxPower: "-3dB"}},
+				{ID: "g2", Name: "west", Wifi24: &RksApRadio{TxPower: "Full"}},
+			},
+			aps: []RksAp{
+				{ApName: "a1", MacAddr: "m1", GroupName: "east", Channel24: 1},
+				{ApName: "a2", MacAddr: "m2", GroupName: "east", Channel24: 6},
+			},
+			configs: map[string]RksApConfig{
+				"m1": {Wifi24: &RksApRadio{TxPower: "Min"}},
+				"m2": {Wifi24: &RksApRadio{Channel: new(int)}},
+			},
+			findings: []string{
+				"TX_POWER 2.4GHz east a1 0 ap txPower Min (zone Full)",
+				"TX_POWER 5GHz east  0 ap group txPower -3dB (zone Full)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := buildRFReport(zoneFrom(t, tt.zone), tt.groups, tt.aps, tt.configs)
+			var findings []string
+			for _, f := range r.Findings {
+				s := fmt.Sprintf("%s %s %s %s %d", f.Kind, f.Band, f.GroupName, f.ApName, f.Channel)
+				if f.Kind == FindingTxPower {
+					s += " " + f.Detail
+				}
+				findings = append(findings, s)
+			}
+			if !reflect.DeepEqual(findings, tt.findings) {
+				t.Errorf("findings = %q; want %q", findings, tt.findings)
+			}
+			var cochannel []string
+			for _, cc := range r.CoChannel {
+				cochannel = append(cochannel, fmt.Sprintf("%s %s %d %v", cc.GroupName, cc.Band, cc.Channel, cc.ApNames))
+			}
+			if !reflect.DeepEqual(cochannel, tt.cochannel) {
+				t.Errorf("co-channel = %q; want %q", cochannel, tt.cochannel)
+			}
+		})
+	}
+}
+
+func TestBuildRFReportChannels(t *testing.T) {
+	aps := []RksAp{
+		{MacAddr: "m1", Channel24: 1, Channel50: 36, Channel50Desc: "36 (80MHz)"},
+		{MacAddr: "m2", Channel24: 1, Channel50: 36, Channel50Desc: "36 (40MHz)"},
+		{MacAddr: "m3", Channel50: 100, Channel50Desc: "100 (80MHz)"},
+	}
+	r := buildRFReport(RksZone{}, nil, aps, nil)
+	want := []ChannelUse{
+		{Band: Band24, Channel: 1, Aps: 2},
+		{Band: Band50, Channel: 36, Width: 40, Aps: 1},
+		{Band: Band50, Channel: 36, Width: 80, Aps: 1},
+		{Band: Band50, Channel: 100, Width: 80, Aps: 1, Dfs: true},
+	}
+	if !reflect.DeepEqual(r.Channels, want) {
+		t.Errorf("channels = %+v; want %+v", r.Channels, want)
+	}
+}
@@ -0,0 +1,351 @@
+package ruckus
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SnapshotFormatVersion the version of the layout Snapshot writes
+const SnapshotFormatVersion = 1
+
+// snapshotManifest the name of the file describing a Snapshot
+const snapshotManifest = "manifest.json"
+
+// SnapshotOptions controls what Snapshot writes
+type SnapshotOptions struct {
+	// write passwords, passphrases and secrets as retrieved.
+	// Default false (redacted)
+	KeepSecrets bool
+}
+
+// SnapshotManifest describes a Snapshot
+type SnapshotManifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Host          string    `json:"host"`
+	Redacted      bool      `json:"redacted"`
+	Files         []string  `json:"files"`
+}
+
+// Snapshot writes a point-in-time tar.gz of everything the Client can read:
+// the Controller, Domains, Zones with their AP Groups and WLANs, and APs.
+// Every file is normalized JSON with stable ordering so Snapshots diff cleanly
+func (c *Client) Snapshot(ctx context.Context, w io.Writer, o SnapshotOptions) error {
+	files, err := c.snapshotFiles(ctx, o)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, name := range sortedFileNames(files) {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(files[name])),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// SnapshotDir writes the Snapshot as a directory tree rooted at dir
+func (c *Client) SnapshotDir(ctx context.Context, dir string, o SnapshotOptions) error {
+	files, err := c.snapshotFiles(ctx, o)
+	if err != nil {
+		return err
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(p, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotFiles retrieves the Controller Configuration keyed by file name
+func (c *Client) snapshotFiles(ctx context.Context, o SnapshotOptions) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	add := func(name string, v interface{}) error {
+		data, err := normalizeJSON(v, !o.KeepSecrets)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %v", name, err)
+		}
+		files[name] = data
+		return ctx.Err()
+	}
+
+	sum, err := c.GetSysSum(RksOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controller: %v", err)
+	}
+	sort.Slice(sum.List, func(i, j int) bool { return sum.List[i].ID < sum.List[j].ID })
+	if err := add("controller.json", sum.List); err != nil {
+		return nil, err
+	}
+
+	domains, err := c.GetDomains(RksOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains: %v", err)
+	}
+	if err := add("domains.json", domains); err != nil {
+		return nil, err
+	}
+
+	zones, err := c.GetAllZones(RksOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zones: %v", err)
+	}
+	for _, z := range zones {
+		zone, err := c.GetZone(z.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get zone %s: %v", z.Name, err)
+		}
+		dir := path.Join("zones", z.ID)
+		if err := add(path.Join(dir, "zone.json"), zone); err != nil {
+			return nil, err
+		}
+		grps, err := c.GetAllApGroups(z.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ap groups of %s: %v", z.Name, err)
+		}
+		var groups []RksApGroup
+		for _, g := range grps {
+			grp, err := c.GetApGroup(z.ID, g.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ap group %s: %v", g.Name, err)
+			}
+			groups = append(groups, grp)
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i].ID < groups[j].ID })
+		if err := add(path.Join(dir, "apgroups.json"), groups); err != nil {
+			return nil, err
+		}
+		wls, err := c.GetZoneWlans(RksOptions{}, z.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wlans of %s: %v", z.Name, err)
+		}
+		var wlans []RksWlanConfig
+		for _, w := range wls {
+			wlan, err := c.GetWlan(z.ID, w.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get wlan %s: %v", w.Name, err)
+			}
+			wlans = append(wlans, wlan)
+		}
+		sort.Slice(wlans, func(i, j int) bool { return wlans[i].ID < wlans[j].ID })
+		if err := add(path.Join(dir, "wlans.json"), wlans); err != nil {
+			return nil, err
+		}
+	}
+
+	aps, err := c.GetAPs(RksOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aps: %v", err)
+	}
+	sort.Slice(aps, func(i, j int) bool { return aps[i].MacAddr < aps[j].MacAddr })
+	if err := add("aps.json", aps); err != nil {
+		return nil, err
+	}
+
+	m := SnapshotManifest{
+		FormatVersion: SnapshotFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		Host:          c.host,
+		Redacted:      !o.KeepSecrets,
+		Files:         sortedFileNames(files),
+	}
+	if err := add(snapshotManifest, m); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func sortedFileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// redacted replaces the value of secret fields in a Snapshot
+const redacted = "**REDACTED**"
+
+// secretFields the (lower cased) names of fields holding secrets
+var secretFields = map[string]bool{
+	"psk":           true,
+	"presharedkey":  true,
+	"sharedsecret":  true,
+	"community":     true,
+	"communityname": true,
+}
+
+// secretSuffixes the endings of the (lower cased) names of fields holding secrets
+var secretSuffixes = []string{"password", "passphrase", "secret"}
+
+// normalizeJSON encodes v as indented JSON with sorted keys
+func normalizeJSON(v interface{}, redact bool) ([]byte, error) {
+	jv, err := toJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	if redact {
+		jv = redactSecrets(jv)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(jv); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func redactSecrets(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range t {
+			if s, ok := fv.(string); ok && s != "" && isSecretField(k) {
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactSecrets(fv)
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = redactSecrets(t[i])
+		}
+	}
+	return v
+}
+
+// isSecretField matches whole field names so that ie dpskName is kept
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	if secretFields[name] {
+		return true
+	}
+	for _, s := range secretSuffixes {
+		if strings.HasSuffix(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// SnapshotChange a file that differs between two Snapshots
+type SnapshotChange struct {
+	File string `json:"file"`
+	// added|removed|modified
+	Status string      `json:"status"`
+	Diffs  []FieldDiff `json:"diffs,omitempty"`
+}
+
+// DiffSnapshots compares two Snapshots (as written by Snapshot) file by file
+// the Manifest is not compared
+func DiffSnapshots(old, new io.Reader) ([]SnapshotChange, error) {
+	oldFiles, err := ReadSnapshot(old)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read old snapshot: %v", err)
+	}
+	newFiles, err := ReadSnapshot(new)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read new snapshot: %v", err)
+	}
+	names := make(map[string]bool)
+	for name := range oldFiles {
+		names[name] = true
+	}
+	for name := range newFiles {
+		names[name] = true
+	}
+	var changes []SnapshotChange
+	for _, name := range sortedFileNames(newFiles) {
+		delete(names, name)
+		if name == snapshotManifest {
+			continue
+		}
+		od, ok := oldFiles[name]
+		if !ok {
+			changes = append(changes, SnapshotChange{File: name, Status: "added"})
+			continue
+		}
+		if bytes.Equal(od, newFiles[name]) {
+			continue
+		}
+		diffs, err := diffJSON(json.RawMessage(od), json.RawMessage(newFiles[name]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s: %v", name, err)
+		}
+		if len(diffs) > 0 {
+			changes = append(changes, SnapshotChange{File: name, Status: "modified", Diffs: diffs})
+		}
+	}
+	var removed []string
+	for name := range names {
+		if name != snapshotManifest {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		changes = append(changes, SnapshotChange{File: name, Status: "removed"})
+	}
+	return changes, nil
+}
+
+// ReadSnapshot reads the files of a Snapshot tar.gz keyed by name
+func ReadSnapshot(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Clean(hdr.Name)] = data
+	}
+}
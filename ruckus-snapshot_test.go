@@ -0,0 +1,209 @@
+package ruckus
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsSecretField(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret bool
+	}{
+		{"passphrase", true},
+		{"apLoginPassword", true},
+		{"sharedSecret", true},
+		{"psk", true},
+		{"communityName", true},
+		{"dpskName", false},
+		{"dpskType", false},
+		{"groupDpskId", false},
+		{"passwordExpiry", false},
+		{"name", false},
+	}
+	for _, tt := range tests {
+		if got := isSecretField(tt.name); got != tt.secret {
+			t.Errorf("isSecretField(%q) = %v; want %v", tt.name, got, tt.secret)
+		}
+	}
+}
+
+// onSnapshot serves a Controller with one Zone of 2 AP Groups (over 2 pages)
+// and a WLAN with a passphrase
+func onSnapshot(f *fakeController) {
+	f.on("GET /controller", `{"list":[{"id":"c1","name":"sz","version":"6.1.0"}]}`)
+	f.on("GET /domains", `{"list":[]}`)
+	f.on("GET /rkszones", `{"list":[{"id":"z1","name":"campus"}]}`)
+	f.on("GET /rkszones/z1", `{"id":"z1","name":"campus","login":{"apLoginName":"admin","apLoginPassword":"hunter2"}}`)
+	f.on("GET /rkszones/z1/apgroups",
+		`{"hasMore":true,"firstIndex":0,"list":[{"id":"g2","name":"lobby"}]}`,
+		`{"hasMore":false,"firstIndex":1,"list":[{"id":"g1","name":"default"}]}`,
+	)
+	f.on("GET /rkszones/z1/apgroups/g1", `{"id":"g1","name":"default"}`)
+	f.on("GET /rkszones/z1/apgroups/g2", `{"id":"g2","name":"lobby"}`)
+	f.on("GET /rkszones/z1/wlans", `{"list":[{"id":"w1","name":"staff"}]}`)
+	f.on("GET /rkszones/z1/wlans/w1", `{"id":"w1","name":"staff","encryption":{"method":"WPA2","passphrase":"secret"}}`)
+	f.on("POST /query/ap", `{"list":[{"apMac":"AA:BB:CC:00:00:02"},{"apMac":"AA:BB:CC:00:00:01"}]}`)
+}
+
+func TestSnapshotDir(t *testing.T) {
+	f, c := newFakeController(t)
+	onSnapshot(f)
+	dir := t.TempDir()
+
+	if err := c.SnapshotDir(context.Background(), dir, SnapshotOptions{}); err != nil {
+		t.Fatalf("SnapshotDir() = %v", err)
+	}
+	read := func(name string) string {
+		d, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(d)
+	}
+	var m SnapshotManifest
+	if err := json.Unmarshal([]byte(read("manifest.json")), &m); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"aps.json", "controller.json", "domains.json", "zones/z1/apgroups.json", "zones/z1/wlans.json", "zones/z1/zone.json"}
+	if !m.Redacted || !reflect.DeepEqual(m.Files, want) {
+		t.Errorf("manifest = %+v; want redacted %v", m, want)
+	}
+	// both pages of AP Groups, sorted by ID
+	var groups []RksApGroup
+	if err := json.Unmarshal([]byte(read("zones/z1/apgroups.json")), &groups); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups[0].ID != "g1" || groups[1].ID != "g2" {
+		t.Errorf("apgroups.json = %+v; want g1 and g2", groups)
+	}
+	if s := read("zones/z1/wlans.json"); strings.Contains(s, "secret") || !strings.Contains(s, redacted) {
+		t.Errorf("wlans.json = %s; want the passphrase redacted", s)
+	}
+	if s := read("zones/z1/zone.json"); strings.Contains(s, "hunter2") || !strings.Contains(s, `"apLoginName": "admin"`) {
+		t.Errorf("zone.json = %s; want only the password redacted", s)
+	}
+	if s := read("aps.json"); strings.Index(s, "00:01") > strings.Index(s, "00:02") {
+		t.Errorf("aps.json = %s; want the aps sorted by mac", s)
+	}
+}
+
+func TestSnapshotKeepSecrets(t *testing.T) {
+	f, c := newFakeController(t)
+	onSnapshot(f)
+	var buf bytes.Buffer
+
+	if err := c.Snapshot(context.Background(), &buf, SnapshotOptions{KeepSecrets: true}); err != nil {
+		t.Fatalf("Snapshot() = %v", err)
+	}
+	files, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() = %v", err)
+	}
+	if len(files) != 7 {
+		t.Errorf("ReadSnapshot() = %d files; want 7", len(files))
+	}
+	if s := string(files["zones/z1/wlans.json"]); !strings.Contains(s, `"passphrase": "secret"`) {
+		t.Errorf("wlans.json = %s; want the passphrase kept", s)
+	}
+}
+
+// tarGz a Snapshot of files; a name ending in / is a directory
+func tarGz(t *testing.T, files map[string]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range sortedFileNames(stringFiles(files)) {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(name, "/") {
+			hdr.Typeflag, hdr.Size, hdr.Mode = tar.TypeDir, 0, 0755
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func stringFiles(files map[string]string) map[string][]byte {
+	b := make(map[string][]byte, len(files))
+	for name, data := range files {
+		b[name] = []byte(data)
+	}
+	return b
+}
+
+func TestReadSnapshot(t *testing.T) {
+	snap := tarGz(t, map[string]string{
+		"zones/":               "",
+		"./zones/z1/zone.json": `{"id":"z1"}`,
+		"aps.json":             `[]`,
+	})
+	files, err := ReadSnapshot(snap)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() = %v", err)
+	}
+	want := map[string][]byte{"zones/z1/zone.json": []byte(`{"id":"z1"}`), "aps.json": []byte(`[]`)}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("ReadSnapshot() = %q; want %q", files, want)
+	}
+	if _, err := ReadSnapshot(strings.NewReader("not a snapshot")); err == nil {
+		t.Error("ReadSnapshot() = nil; want an error for a file that is not gzipped")
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	old := tarGz(t, map[string]string{
+		"manifest.json":      `{"createdAt":"2026-01-01T00:00:00Z"}`,
+		"aps.json":           `[{"apMac":"A","deviceName":"ap1"}]`,
+		"domains.json":       `[]`,
+		"zones/z1/zone.json": `{"id":"z1","countryCode":"US","mesh":null}`,
+		"zones/z2/zone.json": `{"id":"z2"}`,
+	})
+	new := tarGz(t, map[string]string{
+		"manifest.json":      `{"createdAt":"2026-02-01T00:00:00Z"}`,
+		"aps.json":           `[{"apMac":"A","deviceName":"lobby-1"}]`,
+		"domains.json":       `[]`,
+		"zones/z1/zone.json": `{"id":"z1","countryCode":"US","mesh":{}}`,
+		"zones/z3/zone.json": `{"id":"z3"}`,
+	})
+
+	changes, err := DiffSnapshots(old, new)
+	if err != nil {
+		t.Fatalf("DiffSnapshots() = %v", err)
+	}
+	want := []SnapshotChange{
+		{File: "aps.json", Status: "modified", Diffs: []FieldDiff{{Path: "[apMac=A].deviceName", Old: "ap1", New: "lobby-1"}}},
+		{File: "zones/z3/zone.json", Status: "added"},
+		{File: "zones/z2/zone.json", Status: "removed"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("DiffSnapshots() = %+v; want %+v", changes, want)
+	}
+}
+
+func TestDiffSnapshotsUnreadable(t *testing.T) {
+	snap := tarGz(t, map[string]string{"aps.json": `[]`})
+	_, err := DiffSnapshots(snap, strings.NewReader("garbage"))
+	if err == nil || !strings.Contains(err.Error(), "new snapshot") {
+		t.Errorf("DiffSnapshots() = %v; want the new snapshot unreadable", err)
+	}
+}
@@ -0,0 +1,235 @@
+package ruckus
+
+import "fmt"
+
+// Tunnel Types of a Zone
+const (
+	TunnelRuckusGre = "RuckusGRE"
+	TunnelSoftGre   = "SoftGRE"
+	TunnelNone      = "NoTunneled"
+)
+
+// RksRuckusGreProfile properties of a Ruckus GRE Tunnel Profile
+type RksRuckusGreProfile struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// GRE|GREUDP
+	TunnelMode string `json:"tunnelMode,omitempty"`
+	// DISABLE|AES128|AES256
+	TunnelEncryption    string `json:"tunnelEncryption,omitempty"`
+	BranchTunnelEnabled bool   `json:"branchTunnelEnabled,omitempty"`
+	// AUTO|MANUAL
+	TunnelMtuAutoEnabled string `json:"tunnelMtuAutoEnabled,omitempty"`
+	TunnelMtuSize        int    `json:"tunnelMtuSize,omitempty"`
+}
+
+// RksSoftGreProfile properties of a SoftGRE Tunnel Profile
+type RksSoftGreProfile struct {
+	ID               string `json:"id,omitempty"`
+	Name             string `json:"name,omitempty"`
+	Description      string `json:"description,omitempty"`
+	PrimaryGateway   string `json:"primaryGateway,omitempty"`
+	SecondaryGateway string `json:"secondaryGateway,omitempty"`
+	// AUTO|MANUAL
+	TunnelMtuAutoEnabled string `json:"tunnelMtuAutoEnabled,omitempty"`
+	TunnelMtuSize        int    `json:"tunnelMtuSize,omitempty"`
+	KeepAlivePeriod      int    `json:"keepAlivePeriod,omitempty"`
+	KeepAliveRetryTimes  int    `json:"keepAliveRetryTimes,omitempty"`
+}
+
+// RksIpsecProfile properties of an IPsec Tunnel Profile
+type RksIpsecProfile struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	ServerAddr  string `json:"serverAddr,omitempty"`
+	// PSK|Certificate
+	AuthType     string `json:"authType,omitempty"`
+	PreSharedKey string `json:"preSharedKey,omitempty"`
+	// IPV4|IPV6
+	IPMode string `json:"ipMode,omitempty"`
+	// ALWAYS_ON|ON_DEMAND
+	IpsecMode string `json:"ipsecMode,omitempty"`
+	// seconds the Tunnel is kept idle before it is torn down
+	DpdDelay int `json:"dpdDelay,omitempty"`
+}
+
+// RksZoneSoftGre a SoftGRE Tunnel Profile a Zone tunnels to
+type RksZoneSoftGre struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name,omitempty"`
+	AaaAffinityEnabled bool   `json:"aaaAffinityEnabled"`
+}
+
+// tunnelEp the endpoint of the Tunnel Profiles of kind (ruckusgre|softgre|ipsec)
+// of the System or, when zoneID is set, of a Zone
+func tunnelEp(zoneID, kind string) string {
+	if zoneID == "" {
+		return "/profiles/tunnel/" + kind
+	}
+	return fmt.Sprintf("/rkszones/%s/profiles/tunnel/%s", zoneID, kind)
+}
+
+// The Tunnel Profile methods below manage the Profiles of the System when
+// zoneID is empty and those of a Zone otherwise
+
+// GetRuckusGreProfiles retrieves the Ruckus GRE Tunnel Profiles
+func (c *Client) GetRuckusGreProfiles(zoneID string) ([]RksRuckusGreProfile, error) {
+	var ps []RksRuckusGreProfile
+	err := c.getList(tunnelEp(zoneID, "ruckusgre"), RksOptions{}, &ps)
+	return ps, err
+}
+
+// GetRuckusGreProfile retrieves a Ruckus GRE Tunnel Profile
+func (c *Client) GetRuckusGreProfile(zoneID, id string) (RksRuckusGreProfile, error) {
+	var p RksRuckusGreProfile
+	err := c.send("GET", tunnelEp(zoneID, "ruckusgre")+"/"+id, RksOptions{}, nil, &p)
+	return p, err
+}
+
+// CreateRuckusGreProfile creates a Ruckus GRE Tunnel Profile returning its ID
+func (c *Client) CreateRuckusGreProfile(zoneID string, p RksRuckusGreProfile) (string, error) {
+	var created RksObject
+	err := c.send("POST", tunnelEp(zoneID, "ruckusgre"), RksOptions{}, p, &created)
+	return created.ID, err
+}
+
+// UpdateRuckusGreProfile modifies the fields of a Ruckus GRE Tunnel Profile set in p
+func (c *Client) UpdateRuckusGreProfile(zoneID, id string, p RksRuckusGreProfile) error {
+	return c.update(tunnelEp(zoneID, "ruckusgre")+"/"+id, p)
+}
+
+// PatchRuckusGreProfile PATCHes the fields of a Ruckus GRE Tunnel Profile that differ
+// from original, the Profile as retrieved (see Patch)
+func (c *Client) PatchRuckusGreProfile(zoneID string, original, modified RksRuckusGreProfile) error {
+	return c.patch(tunnelEp(zoneID, "ruckusgre")+"/"+original.ID, original, modified)
+}
+
+// DeleteRuckusGreProfile removes a Ruckus GRE Tunnel Profile
+func (c *Client) DeleteRuckusGreProfile(zoneID, id string) error {
+	return c.send("DELETE", tunnelEp(zoneID, "ruckusgre")+"/"+id, RksOptions{}, nil, nil)
+}
+
+// GetSoftGreProfiles retrieves the SoftGRE Tunnel Profiles
+func (c *Client) GetSoftGreProfiles(zoneID string) ([]RksSoftGreProfile, error) {
+	var ps []RksSoftGreProfile
+	err := c.getList(tunnelEp(zoneID, "softgre"), RksOptions{}, &ps)
+	return ps, err
+}
+
+// GetSoftGreProfile retrieves a SoftGRE Tunnel Profile
+func (c *Client) GetSoftGreProfile(zoneID, id string) (RksSoftGreProfile, error) {
+	var p RksSoftGreProfile
+	err := c.send("GET", tunnelEp(zoneID, "softgre")+"/"+id, RksOptions{}, nil, &p)
+	return p, err
+}
+
+// CreateSoftGreProfile creates a SoftGRE Tunnel Profile returning its ID
+func (c *Client) CreateSoftGreProfile(zoneID string, p RksSoftGreProfile) (string, error) {
+	var created RksObject
+	err := c.send("POST", tunnelEp(zoneID, "softgre"), RksOptions{}, p, &created)
+	return created.ID, err
+}
+
+// UpdateSoftGreProfile modifies the fields of a SoftGRE Tunnel Profile set in p
+func (c *Client) UpdateSoftGreProfile(zoneID, id string, p RksSoftGreProfile) error {
+	return c.update(tunnelEp(zoneID, "softgre")+"/"+id, p)
+}
+
+// PatchSoftGreProfile PATCHes the fields of a SoftGRE Tunnel Profile that differ
+// from original, the Profile as retrieved (see Patch)
+func (c *Client) PatchSoftGreProfile(zoneID string, original, modified RksSoftGreProfile) error {
+	return c.patch(tunnelEp(zoneID, "softgre")+"/"+original.ID, original, modified)
+}
+
+// DeleteSoftGreProfile removes a SoftGRE Tunnel Profile
+func (c *Client) DeleteSoftGreProfile(zoneID, id string) error {
+	return c.send("DELETE", tunnelEp(zoneID, "softgre")+"/"+id, RksOptions{}, nil, nil)
+}
+
+// GetIpsecProfiles retrieves the IPsec Tunnel Profiles
+func (c *Client) GetIpsecProfiles(zoneID string) ([]RksIpsecProfile, error) {
+	var ps []RksIpsecProfile
+	err := c.getList(tunnelEp(zoneID, "ipsec"), RksOptions{}, &ps)
+	return ps, err
+}
+
+// GetIpsecProfile retrieves an IPsec Tunnel Profile
+func (c *Client) GetIpsecProfile(zoneID, id string) (RksIpsecProfile, error) {
+	var p RksIpsecProfile
+	err := c.send("GET", tunnelEp(zoneID, "ipsec")+"/"+id, RksOptions{}, nil, &p)
+	return p, err
+}
+
+// CreateIpsecProfile creates an IPsec Tunnel Profile returning its ID
+func (c *Client) CreateIpsecProfile(zoneID string, p RksIpsecProfile) (string, error) {
+	var created RksObject
+	err := c.send("POST", tunnelEp(zoneID, "ipsec"), RksOptions{}, p, &created)
+	return created.ID, err
+}
+
+// UpdateIpsecProfile modifies the fields of an IPsec Tunnel Profile set in p
+func (c *Client) UpdateIpsecProfile(zoneID, id string, p RksIpsecProfile) error {
+	return c.update(tunnelEp(zoneID, "ipsec")+"/"+id, p)
+}
+
+// PatchIpsecProfile PATCHes the fields of an IPsec Tunnel Profile that differ
+// from original, the Profile as retrieved (see Patch)
+func (c *Client) PatchIpsecProfile(zoneID string, original, modified RksIpsecProfile) error {
+	return c.patch(tunnelEp(zoneID, "ipsec")+"/"+original.ID, original, modified)
+}
+
+// DeleteIpsecProfile removes an IPsec Tunnel Profile
+func (c *Client) DeleteIpsecProfile(zoneID, id string) error {
+	return c.send("DELETE", tunnelEp(zoneID, "ipsec")+"/"+id, RksOptions{}, nil, nil)
+}
+
+// ZoneTunnels the Tunnel Profiles a Zone tunnels its WLAN traffic with
+type ZoneTunnels struct {
+	// TunnelRuckusGre|TunnelSoftGre|TunnelNone
+	TunnelType string
+	// used when TunnelType is TunnelRuckusGre
+	RuckusGreProfileID string
+	// used when TunnelType is TunnelSoftGre
+	SoftGreProfileIDs []string
+	// encrypts the SoftGRE|Ruckus GRE Tunnel with an IPsec Profile when set
+	IpsecProfileID string
+}
+
+// AssignZoneTunnelProfiles sets the Tunnel Type and Profiles of a Zone
+// clearing the Profiles the Tunnel Type no longer uses
+func (c *Client) AssignZoneTunnelProfiles(zoneID string, t ZoneTunnels) error {
+	body := struct {
+		TunnelType      string           `json:"tunnelType"`
+		TunnelProfile   *RksObject       `json:"tunnelProfile"`
+		SoftGreProfiles []RksZoneSoftGre `json:"softGreTunnelProflies,omitempty"`
+		IpsecTunnelMode string           `json:"ipsecTunnelMode"`
+		IpsecProfile    *RksObject       `json:"ipsecProfile"`
+	}{TunnelType: t.TunnelType, IpsecTunnelMode: "DISABLE"}
+	switch t.TunnelType {
+	case TunnelRuckusGre:
+		if t.RuckusGreProfileID == "" {
+			return fmt.Errorf("a ruckus gre profile is required")
+		}
+		body.TunnelProfile = &RksObject{ID: t.RuckusGreProfileID}
+	case TunnelSoftGre:
+		if len(t.SoftGreProfileIDs) == 0 {
+			return fmt.Errorf("at least one softgre profile is required")
+		}
+		for _, id := range t.SoftGreProfileIDs {
+			body.SoftGreProfiles = append(body.SoftGreProfiles, RksZoneSoftGre{ID: id})
+		}
+	case TunnelNone:
+	default:
+		return fmt.Errorf("invalid tunnel type %q", t.TunnelType)
+	}
+	if t.IpsecProfileID != "" {
+		if t.TunnelType == TunnelNone {
+			return fmt.Errorf("an ipsec profile requires a tunnel")
+		}
+		body.IpsecTunnelMode = map[string]string{TunnelRuckusGre: "RUCKUS_GRE", TunnelSoftGre: "SOFT_GRE"}[t.TunnelType]
+		body.IpsecProfile = &RksObject{ID: t.IpsecProfileID}
+	}
+	return c.update(fmt.Sprintf("/rkszones/%s", zoneID), body)
+}
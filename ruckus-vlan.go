@@ -0,0 +1,313 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VlanRange a range of VLAN IDs (Start == End for a single VLAN)
+type VlanRange struct {
+	Start int
+	End   int
+}
+
+func (r VlanRange) String() string {
+	if r.Start == r.End {
+		return strconv.Itoa(r.Start)
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// Contains reports whether vlan is in the range
+func (r VlanRange) Contains(vlan int) bool {
+	return vlan >= r.Start && vlan <= r.End
+}
+
+// VlanList a list of VLAN ranges encoded as the Controller does (ie "10-20,30")
+type VlanList []VlanRange
+
+// ParseVlanList parses a list of VLANs and ranges (ie "10-20,30")
+func ParseVlanList(s string) (VlanList, error) {
+	var l VlanList
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.SplitN(part, "-", 2)
+		start, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid vlan %q", part)
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil {
+				return nil, fmt.Errorf("invalid vlan range %q", part)
+			}
+		}
+		l = append(l, VlanRange{Start: start, End: end})
+	}
+	return l, nil
+}
+
+func (l VlanList) String() string {
+	parts := make([]string, len(l))
+	for i, r := range l {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// Contains reports whether vlan is in the list
+func (l VlanList) Contains(vlan int) bool {
+	for _, r := range l {
+		if r.Contains(vlan) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps the VLAN ranges l and o have in common
+func (l VlanList) Overlaps(o VlanList) VlanList {
+	var common VlanList
+	for _, a := range l {
+		for _, b := range o {
+			start, end := a.Start, a.End
+			if b.Start > start {
+				start = b.Start
+			}
+			if b.End < end {
+				end = b.End
+			}
+			if start <= end {
+				common = append(common, VlanRange{Start: start, End: end})
+			}
+		}
+	}
+	return common
+}
+
+// MarshalJSON encodes the list as a string (ie "10-20,30")
+func (l VlanList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.String())
+}
+
+// UnmarshalJSON decodes a list encoded as a string (ie "10-20,30")
+func (l *VlanList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseVlanList(s)
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// VlanPoolAlgorithm how a VLAN Pool assigns VLANs to Clients
+type VlanPoolAlgorithm string
+
+// VlanPoolMacHash assigns VLANs by hashing the MAC of the Client
+const VlanPoolMacHash VlanPoolAlgorithm = "MAC_HASH"
+
+// RksVlanPool properties of a VLAN Pool
+type RksVlanPool struct {
+	ID          string            `json:"id,omitempty"`
+	DomainID    string            `json:"domainId,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Algorithm   VlanPoolAlgorithm `json:"algo,omitempty"`
+	Pool        VlanList          `json:"pool,omitempty"`
+}
+
+// GetVlanPools retrieves the VLAN Pools of the System
+func (c *Client) GetVlanPools(o RksOptions) ([]RksVlanPool, error) {
+	var pools []RksVlanPool
+	err := c.queryList("/vlanpoolings/query", newQuery("name"), o, &pools)
+	return pools, err
+}
+
+// GetVlanPool retrieves a VLAN Pool
+func (c *Client) GetVlanPool(id string) (RksVlanPool, error) {
+	var p RksVlanPool
+	err := c.send("GET", fmt.Sprintf("/vlanpoolings/%s", id), RksOptions{}, nil, &p)
+	return p, err
+}
+
+// CreateVlanPool creates a VLAN Pool returning its ID
+// see CheckVlanPool to validate it against a Zone first
+func (c *Client) CreateVlanPool(p RksVlanPool) (string, error) {
+	var created RksObject
+	err := c.send("POST", "/vlanpoolings", RksOptions{}, p, &created)
+	return created.ID, err
+}
+
+// UpdateVlanPool modifies the fields of a VLAN Pool set in p
+func (c *Client) UpdateVlanPool(id string, p RksVlanPool) error {
+	return c.update(fmt.Sprintf("/vlanpoolings/%s", id), p)
+}
+
+// PatchVlanPool PATCHes the fields of modified that differ from original,
+// the Pool as retrieved (see Patch); unlike UpdateVlanPool it clears the
+// fields modified leaves empty
+func (c *Client) PatchVlanPool(original, modified RksVlanPool) error {
+	return c.patch(fmt.Sprintf("/vlanpoolings/%s", original.ID), original, modified)
+}
+
+// DeleteVlanPool removes a VLAN Pool
+func (c *Client) DeleteVlanPool(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/vlanpoolings/%s", id), RksOptions{}, nil, nil)
+}
+
+// ValidateVlanPool checks that the VLANs of p are valid and, unless the Zone
+// allows VLAN overlapping, that they overlap neither the AP Management VLAN
+// of zone nor the VLANs of the other Pools in use there (existing).
+// Every problem found is reported in the returned error
+func ValidateVlanPool(p RksVlanPool, zone RksZone, existing []RksVlanPool) error {
+	var problems []string
+	if len(p.Pool) == 0 {
+		problems = append(problems, "pool has no vlans")
+	}
+	for _, r := range p.Pool {
+		if r.Start < 1 || r.End > 4094 || r.Start > r.End {
+			problems = append(problems, fmt.Sprintf("invalid vlan range %s", r))
+		}
+	}
+	for i := range p.Pool {
+		for j := i + 1; j < len(p.Pool); j++ {
+			if o := (VlanList{p.Pool[i]}).Overlaps(VlanList{p.Pool[j]}); len(o) > 0 {
+				problems = append(problems, fmt.Sprintf("ranges %s and %s overlap", p.Pool[i], p.Pool[j]))
+			}
+		}
+	}
+	if !zone.VlanOverlappingEnabled {
+		if mgmt := zone.ApMgmtVlan.ID; mgmt > 0 && p.Pool.Contains(mgmt) {
+			problems = append(problems, fmt.Sprintf("vlan %d is the ap management vlan of zone %s", mgmt, zone.Name))
+		}
+		others := append([]RksVlanPool(nil), existing...)
+		sort.Slice(others, func(i, j int) bool { return others[i].Name < others[j].Name })
+		for _, e := range others {
+			if e.ID != "" && e.ID == p.ID {
+				continue
+			}
+			if o := p.Pool.Overlaps(e.Pool); len(o) > 0 {
+				problems = append(problems, fmt.Sprintf("vlans %s overlap pool %s", o, e.Name))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("vlan pool %s: %s", p.Name, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// CheckVlanPool validates p (see ValidateVlanPool) against a Zone and the
+// VLAN Pools the WLANs of the Zone use
+func (c *Client) CheckVlanPool(zoneID string, p RksVlanPool) error {
+	zone, err := c.GetZone(zoneID)
+	if err != nil {
+		return err
+	}
+	wlans, err := c.GetZoneWlans(RksOptions{}, zoneID)
+	if err != nil {
+		return fmt.Errorf("failed to get wlans: %v", err)
+	}
+	used := make(map[string]bool)
+	for _, w := range wlans {
+		wlan, err := c.GetWlan(zoneID, w.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get wlan %s: %v", w.Name, err)
+		}
+		if wlan.VlanPooling != nil && wlan.VlanPooling.ID != "" {
+			used[wlan.VlanPooling.ID] = true
+		}
+	}
+	var existing []RksVlanPool
+	if len(used) > 0 {
+		pools, err := c.GetVlanPools(RksOptions{})
+		if err != nil {
+			return err
+		}
+		for _, pool := range pools {
+			if used[pool.ID] {
+				existing = append(existing, pool)
+			}
+		}
+	}
+	return ValidateVlanPool(p, zone, existing)
+}
+
+// RksUserTrafficProfile properties of a User Traffic Profile
+// (the Rate Limits and Access Controls applied to the traffic of a User)
+type RksUserTrafficProfile struct {
+	ID            string `json:"id,omitempty"`
+	DomainID      string `json:"domainId,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	DefaultAction string `json:"defaultAction,omitempty"`
+	// Mbps; 0 unlimited
+	UplinkRateLimiting   float64     `json:"uplinkRateLimitingMbps,omitempty"`
+	DownlinkRateLimiting float64     `json:"downlinkRateLimitingMbps,omitempty"`
+	Rules                []RksL3Rule `json:"ipAclRules,omitempty"`
+}
+
+// Validate checks the fields of the Profile that are set and all its Rules
+func (p RksUserTrafficProfile) Validate() error {
+	if err := validAction("default action", p.DefaultAction); err != nil {
+		return err
+	}
+	if p.UplinkRateLimiting < 0 || p.DownlinkRateLimiting < 0 {
+		return fmt.Errorf("rate limits cannot be negative")
+	}
+	for i, r := range p.Rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("rule %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// GetUserTrafficProfiles retrieves the User Traffic Profiles of the System
+func (c *Client) GetUserTrafficProfiles(o RksOptions) ([]RksUserTrafficProfile, error) {
+	var utps []RksUserTrafficProfile
+	err := c.getList("/profiles/utp", o, &utps)
+	return utps, err
+}
+
+// GetUserTrafficProfile retrieves a User Traffic Profile
+func (c *Client) GetUserTrafficProfile(id string) (RksUserTrafficProfile, error) {
+	var utp RksUserTrafficProfile
+	err := c.send("GET", fmt.Sprintf("/profiles/utp/%s", id), RksOptions{}, nil, &utp)
+	return utp, err
+}
+
+// CreateUserTrafficProfile validates and creates a User Traffic Profile returning its ID
+func (c *Client) CreateUserTrafficProfile(p RksUserTrafficProfile) (string, error) {
+	var created RksObject
+	err := c.submitPolicy("POST", "/profiles/utp", p.Name, p, &created)
+	return created.ID, err
+}
+
+// UpdateUserTrafficProfile validates and modifies the fields of a User Traffic Profile set in p
+func (c *Client) UpdateUserTrafficProfile(id string, p RksUserTrafficProfile) error {
+	return c.submitPolicy("PATCH", fmt.Sprintf("/profiles/utp/%s", id), p.Name, p, nil)
+}
+
+// PatchUserTrafficProfile validates modified and PATCHes its fields that
+// differ from original, the Profile as retrieved (see Patch); unlike
+// UpdateUserTrafficProfile it clears the fields modified leaves empty
+// (ie a Rate Limit back to 0, unlimited)
+func (c *Client) PatchUserTrafficProfile(original, modified RksUserTrafficProfile) error {
+	return c.patchPolicy(fmt.Sprintf("/profiles/utp/%s", original.ID), original, modified)
+}
+
+// DeleteUserTrafficProfile removes a User Traffic Profile
+func (c *Client) DeleteUserTrafficProfile(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/profiles/utp/%s", id), RksOptions{}, nil, nil)
+}
@@ -0,0 +1,25 @@
+package ruckus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckVlanPoolOnlyAgainstPoolsOfTheZone(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("GET /rkszones/z1", `{"id":"z1","name":"campus","vlanOverlappingEnabled":false}`)
+	f.on("GET /rkszones/z1/wlans", `{"list":[{"id":"w1","name":"staff"}]}`)
+	f.on("GET /rkszones/z1/wlans/w1", `{"id":"w1","vlanPooling":{"id":"used"}}`)
+	f.on("POST /vlanpoolings/query", `{"list":[{"id":"used","name":"staff-pool","pool":"100-110"},{"id":"elsewhere","name":"other-zone","pool":"200-210"}]}`)
+
+	// overlaps only a pool no WLAN of the zone uses
+	p := RksVlanPool{Name: "new", Pool: VlanList{{200, 205}}}
+	if err := c.CheckVlanPool("z1", p); err != nil {
+		t.Errorf("CheckVlanPool() = %v; want no error", err)
+	}
+	p.Pool = VlanList{{105, 120}}
+	err := c.CheckVlanPool("z1", p)
+	if err == nil || !strings.Contains(err.Error(), "staff-pool") {
+		t.Errorf("CheckVlanPool() = %v; want an overlap with staff-pool", err)
+	}
+}
@@ -0,0 +1,126 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetWlans retrieves every WLAN of the Controller along with its Clients and Traffic
+func (c *Client) GetWlans(o RksOptions) ([]RksWlan, error) {
+	var wlans []RksWlan
+	err := c.queryList("/query/wlan", newQuery("name"), o, &wlans)
+	return wlans, err
+}
+
+// RksWlanConfig the Configuration of a WLAN of a Zone
+type RksWlanConfig struct {
+	ID          string             `json:"id,omitempty"`
+	ZoneID      string             `json:"zoneId,omitempty"`
+	Name        string             `json:"name,omitempty"`
+	SSID        string             `json:"ssid,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Encryption  *RksWlanEncryption `json:"encryption,omitempty"`
+	Vlan        *RksWlanVlan       `json:"vlan,omitempty"`
+	// the VLAN Pool Clients are assigned VLANs from (see RksVlanPool)
+	VlanPooling *RksObject `json:"vlanPooling,omitempty"`
+	// the Access Control Policies the WLAN enforces (see GetWlanPolicies)
+	AccessControl     *RksWlanAccessControl `json:"accessControl,omitempty"`
+	FirewallProfileID string                `json:"firewallProfileId,omitempty"`
+	rawDoc
+}
+
+type rksWlanConfig RksWlanConfig
+
+// UnmarshalJSON decodes a WLAN keeping the document (see MergeRaw)
+func (w *RksWlanConfig) UnmarshalJSON(data []byte) error {
+	var v rksWlanConfig
+	err := json.Unmarshal(data, &v)
+	v.keep(data)
+	*w = RksWlanConfig(v)
+	return err
+}
+
+// MarshalJSON encodes a WLAN merged into the document it was retrieved as
+// (see MergeRaw) so the fields it does not model are written back
+func (w RksWlanConfig) MarshalJSON() ([]byte, error) {
+	typed, err := json.Marshal(rksWlanConfig(w))
+	if err != nil {
+		return nil, err
+	}
+	return mergeRaw(w.Raw(), typed)
+}
+
+// RksWlanAccessControl the Access Control Policies attached to a WLAN
+type RksWlanAccessControl struct {
+	L2Acl        *RksObject `json:"l2ACL,omitempty"`
+	L3Acp        *RksObject `json:"l3ACP,omitempty"`
+	DevicePolicy *RksObject `json:"devicePolicy,omitempty"`
+}
+
+// RksWlanEncryption the Encryption of a WLAN
+type RksWlanEncryption struct {
+	// WPA2|WPA3|WPA23_MIXED|None...
+	Method string `json:"method,omitempty" yaml:"method"`
+	// AES|AES_GCMP_256...
+	Algorithm  string `json:"algorithm,omitempty" yaml:"algorithm"`
+	Passphrase string `json:"passphrase,omitempty" yaml:"passphrase"`
+	MfpOption  string `json:"mfp,omitempty" yaml:"mfp"`
+}
+
+// RksWlanVlan the VLAN Settings of a WLAN
+type RksWlanVlan struct {
+	AccessVlan int `json:"accessVlan,omitempty" yaml:"accessVlan"`
+}
+
+// GetZoneWlans retrieves the Names and IDs of the WLANs of a Zone
+func (c *Client) GetZoneWlans(o RksOptions, zoneID string) ([]RksObject, error) {
+	var wlans []RksObject
+	err := c.getList(fmt.Sprintf("/rkszones/%s/wlans", zoneID), o, &wlans)
+	return wlans, err
+}
+
+// GetWlan retrieves the Configuration of a WLAN of a Zone
+func (c *Client) GetWlan(zoneID, id string) (RksWlanConfig, error) {
+	var wlan RksWlanConfig
+	ep := fmt.Sprintf("/rkszones/%s/wlans/%s", zoneID, id)
+	err := c.send("GET", ep, RksOptions{}, nil, &wlan)
+	return wlan, err
+}
+
+// CreateWlan creates a WLAN in a Zone returning its ID
+func (c *Client) CreateWlan(zoneID string, w RksWlanConfig) (string, error) {
+	var created RksObject
+	ep := fmt.Sprintf("/rkszones/%s/wlans", zoneID)
+	err := c.send("POST", ep, RksOptions{}, w, &created)
+	return created.ID, err
+}
+
+// UpdateWlan modifies the fields of a WLAN set in w
+func (c *Client) UpdateWlan(zoneID, id string, w RksWlanConfig) error {
+	ep := fmt.Sprintf("/rkszones/%s/wlans/%s", zoneID, id)
+	return c.update(ep, w)
+}
+
+// PatchWlan PATCHes the fields of a WLAN that differ from original,
+// the WLAN as retrieved (see Patch); unlike UpdateWlan it clears the
+// fields modified leaves empty
+func (c *Client) PatchWlan(zoneID string, original, modified RksWlanConfig) error {
+	return c.patch(fmt.Sprintf("/rkszones/%s/wlans/%s", zoneID, original.ID), original, modified)
+}
+
+// SaveWlan replaces a WLAN of a Zone with w merged into the document it
+// was retrieved as (see MergeRaw) so fields w does not model are kept
+func (c *Client) SaveWlan(zoneID string, w RksWlanConfig) error {
+	doc, err := MergeRaw(w)
+	if err != nil {
+		return err
+	}
+	ep := fmt.Sprintf("/rkszones/%s/wlans/%s", zoneID, w.ID)
+	return c.send("PUT", ep, RksOptions{}, doc, nil)
+}
+
+// DeleteWlan removes a WLAN of a Zone
+func (c *Client) DeleteWlan(zoneID, id string) error {
+	ep := fmt.Sprintf("/rkszones/%s/wlans/%s", zoneID, id)
+	return c.send("DELETE", ep, RksOptions{}, nil, nil)
+}
@@ -0,0 +1,214 @@
+package ruckus
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RksApLogin the Credentials APs of a Zone are managed with
+type RksApLogin struct {
+	ApLoginName     string `json:"apLoginName" yaml:"apLoginName"`
+	ApLoginPassword string `json:"apLoginPassword" yaml:"apLoginPassword"`
+}
+
+// RksZoneReq the fields of a Zone that may be set on Create|Update
+type RksZoneReq struct {
+	DomainID    string      `json:"domainId,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	CountryCode string      `json:"countryCode,omitempty"`
+	Login       *RksApLogin `json:"login,omitempty"`
+}
+
+// GetAllZones retrieves the Names and IDs of every Zone
+// paging through the list however many Zones there are
+func (c *Client) GetAllZones(o RksOptions) ([]RksObject, error) {
+	var zones []RksObject
+	err := c.getList("/rkszones", o, &zones)
+	return zones, err
+}
+
+// CreateZone creates a Zone returning its ID
+func (c *Client) CreateZone(z RksZoneReq) (string, error) {
+	var created RksObject
+	err := c.send("POST", "/rkszones", RksOptions{}, z, &created)
+	return created.ID, err
+}
+
+// UpdateZone modifies the fields of a Zone set in z
+func (c *Client) UpdateZone(id string, z RksZoneReq) error {
+	return c.update(fmt.Sprintf("/rkszones/%s", id), z)
+}
+
+// SaveZone replaces a Zone with z merged into the document it was
+// retrieved as (see MergeRaw) so fields z does not model are kept
+func (c *Client) SaveZone(z RksZone) error {
+	doc, err := MergeRaw(z)
+	if err != nil {
+		return err
+	}
+	return c.send("PUT", fmt.Sprintf("/rkszones/%s", z.ID), RksOptions{}, doc, nil)
+}
+
+// DeleteZone removes a Zone
+func (c *Client) DeleteZone(id string) error {
+	return c.send("DELETE", fmt.Sprintf("/rkszones/%s", id), RksOptions{}, nil, nil)
+}
+
+// rksZone has the fields of RksZone without its JSON methods
+type rksZone RksZone
+
+// UnmarshalJSON decodes a Zone keeping the document it was retrieved as
+// and setting Unknown. As with json.Unmarshal a value of an unexpected type
+// is reported (*json.UnmarshalTypeError) once the rest is decoded
+func (z *RksZone) UnmarshalJSON(data []byte) error {
+	var v rksZone
+	err := json.Unmarshal(data, &v)
+	if _, ok := err.(*json.UnmarshalTypeError); err != nil && !ok {
+		return err
+	}
+	v.keep(data)
+	v.Unknown = unknownFields(data, reflect.TypeOf(rksZone{}))
+	*z = RksZone(v)
+	return err
+}
+
+// MarshalJSON encodes a Zone merged into the document it was retrieved as
+// (see MergeRaw): the fields it does not model are kept at any depth and
+// the top level ones take their value from Unknown
+func (z RksZone) MarshalJSON() ([]byte, error) {
+	typed, err := json.Marshal(rksZone(z))
+	if err != nil {
+		return nil, err
+	}
+	doc, err := mergeRaw(z.Raw(), typed)
+	if err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(rksZone{})
+	return withUnknown(doc, jsonFieldNames(t), unknownFields(z.Raw(), t), z.Unknown)
+}
+
+// Coordinate a Latitude|Longitude; the Controller sends it as a number
+// or as a string ("" when unset)
+type Coordinate float64
+
+// UnmarshalJSON decodes a Coordinate from a number or a string
+func (c *Coordinate) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*c = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid coordinate %s: %v", data, err)
+	}
+	*c = Coordinate(f)
+	return nil
+}
+
+// RksCustomTimezone a Timezone of a Zone not among the System Timezones
+type RksCustomTimezone struct {
+	Abbreviation    string        `json:"abbreviation"`
+	GmtOffset       int           `json:"gmtOffset"`
+	GmtOffsetMinute int           `json:"gmtOffsetMinute"`
+	Start           *RksDstChange `json:"start"`
+	End             *RksDstChange `json:"end"`
+}
+
+// RksDstChange when Daylight Saving Time starts|ends
+type RksDstChange struct {
+	Month int `json:"month"`
+	// the Week of the Month (1-5) and Day of the Week (0 Sunday)
+	Week int `json:"week"`
+	Day  int `json:"day"`
+	Hour int `json:"hour"`
+}
+
+// RksZoneMesh the Mesh Settings of a Zone
+type RksZoneMesh struct {
+	SSID             string `json:"ssid"`
+	Passphrase       string `json:"passphrase"`
+	ZeroTouchEnabled bool   `json:"zeroTouchEnabled"`
+	MeshRadioIdx     *int   `json:"meshRadioIdx"`
+}
+
+// RksZoneSyslog where the APs of a Zone send their Syslog
+type RksZoneSyslog struct {
+	Address          string `json:"address"`
+	Port             int    `json:"port"`
+	SecondaryAddress string `json:"secondaryAddress"`
+	SecondaryPort    int    `json:"secondaryPort"`
+	// ie Local0..Local7|Keep_Original
+	Facility string `json:"facility"`
+	// ie Emergency..Debug
+	Priority string `json:"priority"`
+	// UDP|TCP
+	Protocol string `json:"protocol"`
+	// PRIMARY_AND_SECONDARY|ACTIVE_STANDBY
+	RedundancyMode string `json:"redundancyMode"`
+	// GENERAL_LOGS|CLIENT_FLOW|ALL
+	FlowLevel string `json:"flowLevel"`
+}
+
+// RksSmartMonitor the Smart Monitor (uplink check) of the APs of a Zone
+type RksSmartMonitor struct {
+	IntervalInSec  int `json:"intervalInSec"`
+	RetryThreshold int `json:"retryThreshold"`
+}
+
+// RksClientAdmissionControl the Client Admission Control of a Radio
+type RksClientAdmissionControl struct {
+	Enabled               bool    `json:"enabled"`
+	MaxRadioLoadPercent   int     `json:"maxRadioLoadPercent"`
+	MinClientCount        int     `json:"minClientCount"`
+	MinClientThroughputMb float64 `json:"minClientThroughputMbps"`
+}
+
+// RksClientLoadBalancing the Client Load Balancing of a Radio
+type RksClientLoadBalancing struct {
+	AdjacentRadioThreshold int `json:"adjacentRadioThreshold"`
+}
+
+// RksDhcpSiteAp an AP serving DHCP|NAT to a Zone
+type RksDhcpSiteAp struct {
+	ApMac           string `json:"apMac"`
+	ApName          string `json:"apName"`
+	ApServerEnabled bool   `json:"apServerEnabled"`
+	ApServerPrimary bool   `json:"apServerPrimary"`
+	ApServerIP      string `json:"apServerIp"`
+}
+
+// RksSnmpTarget where SNMP Traps are sent
+type RksSnmpTarget struct {
+	Address string `json:"address"`
+	Port    int    `json:"port"`
+}
+
+// RksSnmpV2Agent an SNMPv2 Community of the APs of a Zone
+type RksSnmpV2Agent struct {
+	CommunityName string          `json:"communityName"`
+	ReadEnabled   bool            `json:"readEnabled"`
+	WriteEnabled  bool            `json:"writeEnabled"`
+	TrapEnabled   bool            `json:"trapEnabled"`
+	TrapTargets   []RksSnmpTarget `json:"trapTargets"`
+}
+
+// RksSnmpV3Agent an SNMPv3 User of the APs of a Zone
+type RksSnmpV3Agent struct {
+	UserName string `json:"userName"`
+	// None|MD5|SHA
+	AuthProtocol string `json:"authProtocol"`
+	AuthPassword string `json:"authPassword"`
+	// None|DES|AES
+	PrivProtocol string          `json:"privProtocol"`
+	PrivPassword string          `json:"privPassword"`
+	ReadEnabled  bool            `json:"readEnabled"`
+	WriteEnabled bool            `json:"writeEnabled"`
+	TrapEnabled  bool            `json:"trapEnabled"`
+	TrapTargets  []RksSnmpTarget `json:"trapTargets"`
+}
@@ -0,0 +1,185 @@
+package ruckus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func loadZone(t *testing.T, name string) ([]byte, RksZone) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var z RksZone
+	if err := json.Unmarshal(data, &z); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return data, z
+}
+
+func TestZoneRoundTrip(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "zone_*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no zone fixtures: %v", err)
+	}
+	for _, f := range files {
+		data, z := loadZone(t, filepath.Base(f))
+		got, err := json.Marshal(z)
+		if err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		// json.Marshal compacts what a MarshalJSON returns
+		var want bytes.Buffer
+		if err := json.Compact(&want, data); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want.Bytes()) {
+			t.Errorf("%s: round trip changed the zone\ngot  %s\nwant %s", f, got, want.Bytes())
+		}
+	}
+}
+
+func TestZoneKeepsNestedUnknown(t *testing.T) {
+	_, z := loadZone(t, "zone_unknown.json")
+	z.Name = "Building-A1"
+	z.Wifi24.TxPower = "-3dB"
+	z.Mesh.ZeroTouchEnabled = false
+
+	doc, err := MergeRaw(z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Name   string `json:"name"`
+		Wifi24 struct {
+			TxPower           string          `json:"txPower"`
+			ChannelSelectMode json.RawMessage `json:"channelSelectMode"`
+		} `json:"wifi24"`
+		Mesh     map[string]interface{} `json:"mesh"`
+		Login    map[string]interface{} `json:"login"`
+		Latitude json.RawMessage        `json:"latitude"`
+		Floor    json.RawMessage        `json:"floorPlan"`
+	}
+	if err := json.Unmarshal(doc, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Building-A1" || got.Wifi24.TxPower != "-3dB" || got.Mesh["zeroTouchEnabled"] != false {
+		t.Errorf("changes lost: %s", doc)
+	}
+	if string(got.Wifi24.ChannelSelectMode) != `{"mode":"ChannelFly","mtbc":100}` {
+		t.Errorf("wifi24.channelSelectMode = %s", got.Wifi24.ChannelSelectMode)
+	}
+	if got.Mesh["meshEnableAutoRecovery"] != true {
+		t.Errorf("mesh.meshEnableAutoRecovery lost: %v", got.Mesh)
+	}
+	if _, ok := got.Login["apLoginSshKeyEnabled"]; !ok {
+		t.Errorf("login.apLoginSshKeyEnabled lost: %v", got.Login)
+	}
+	if string(got.Latitude) != `"32.7767"` {
+		t.Errorf("latitude = %s; want it as retrieved", got.Latitude)
+	}
+	if string(got.Floor) != `{"defaultFloor":{"unit":"floor","value":2},"floorCount":3}` {
+		t.Errorf("floorPlan = %s", got.Floor)
+	}
+}
+
+func TestZoneUnknown(t *testing.T) {
+	_, z := loadZone(t, "zone_unknown.json")
+	for _, k := range []string{"venueCode", "zoneTags", "floorPlan"} {
+		if _, ok := z.Unknown[k]; !ok {
+			t.Errorf("Unknown lacks %s", k)
+		}
+	}
+	if _, ok := z.Unknown["wifi24"]; ok || len(z.Unknown) != 3 {
+		t.Errorf("Unknown = %v", z.Unknown)
+	}
+
+	// set, changed and removed unknown fields are encoded so
+	z.Unknown["venueCode"] = json.RawMessage(`"DAL-02"`)
+	z.Unknown["weatherAlerts"] = json.RawMessage(`true`)
+	delete(z.Unknown, "zoneTags")
+	// a field the Zone models keeps its value
+	z.Unknown["name"] = json.RawMessage(`"ignored"`)
+	doc, err := json.Marshal(z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got["venueCode"]) != `"DAL-02"` || string(got["weatherAlerts"]) != `true` {
+		t.Errorf("unknown changes lost: %s", doc)
+	}
+	if _, ok := got["zoneTags"]; ok {
+		t.Errorf("removed zoneTags encoded: %s", doc)
+	}
+	if string(got["name"]) != fmt.Sprintf("%q", z.Name) || got["floorPlan"] == nil {
+		t.Errorf("known or untouched fields changed: %s", doc)
+	}
+
+	// a Zone built in code has unknown fields too
+	built := RksZone{Name: "new", Unknown: map[string]json.RawMessage{"venueCode": json.RawMessage(`"X"`)}}
+	if doc, err := json.Marshal(built); err != nil || !bytes.Contains(doc, []byte(`"venueCode":"X"`)) {
+		t.Errorf("json.Marshal() = %s, %v; want venueCode", doc, err)
+	}
+}
+
+func TestZoneClearField(t *testing.T) {
+	_, original := loadZone(t, "zone_unknown.json")
+	if original.Mesh == nil {
+		t.Fatal("fixture has no mesh")
+	}
+	z := original
+	z.Mesh = nil
+
+	doc, err := MergeRaw(z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &got); err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := got["mesh"]; !ok || string(m) != "null" {
+		t.Errorf("SaveZone body mesh = %s; want null", m)
+	}
+
+	f, c := newFakeController(t)
+	f.on("PATCH /rkszones/"+original.ID, `{}`)
+	if err := c.PatchZone(original, z); err != nil {
+		t.Fatal(err)
+	}
+	if reqs := f.received("PATCH /rkszones/" + original.ID); len(reqs) != 1 || reqs[0].Body != `{"mesh":null}` {
+		t.Errorf("PatchZone() sent %v; want {\"mesh\":null}", reqs)
+	}
+}
+
+func TestZoneCoordinates(t *testing.T) {
+	tests := []struct {
+		file     string
+		lat, lng Coordinate
+	}{
+		{"zone_unknown.json", 32.7767, -96.7970},
+		{"zone_numbers.json", 32.78, -96.8},
+		{"zone_unset.json", 0, 0},
+	}
+	for _, tt := range tests {
+		_, z := loadZone(t, tt.file)
+		if z.Latitude == nil || z.Longitude == nil {
+			t.Errorf("%s: coordinates not decoded", tt.file)
+			continue
+		}
+		if *z.Latitude != tt.lat || *z.Longitude != tt.lng {
+			t.Errorf("%s: coordinates = %v,%v; want %v,%v", tt.file, *z.Latitude, *z.Longitude, tt.lat, tt.lng)
+		}
+	}
+	var c Coordinate
+	if err := json.Unmarshal([]byte(`"north"`), &c); err == nil {
+		t.Errorf("a coordinate that is not a number decoded")
+	}
+}
@@ -1,11 +1,15 @@
 package ruckus
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,7 +22,22 @@ type Client struct {
 	username string
 	password string
 
-	http          *http.Client
+	// Retry controls how failed requests are retried
+	Retry RetryPolicy
+	// Limiter optionally bounds the request rate of the Client
+	// it may be shared by many Clients talking to the same Controller
+	Limiter *RateLimiter
+
+	http *http.Client
+	sess *session
+	// the Domain every Request is scoped to (see InDomain)
+	domainID string
+}
+
+// session holds the serviceTicket issued by the Controller
+// it is guarded so a single Client may be used by many goroutines
+type session struct {
+	mu            sync.RWMutex
 	serviceTicket string
 }
 
@@ -29,6 +48,7 @@ func New(apiVersion, host, user, pass string, ignoreSSL bool) *Client {
 		host:     host,
 		username: user,
 		password: pass,
+		Retry:    DefaultRetryPolicy,
 		http: &http.Client{
 			Transport: &http.Transport{
 				TLSClientConfig: &tls.Config{
@@ -37,9 +57,22 @@ func New(apiVersion, host, user, pass string, ignoreSSL bool) *Client {
 			},
 			Timeout: 120 * time.Second,
 		},
+		sess: &session{},
 	}
 }
 
+func (c *Client) ticket() string {
+	c.sess.mu.RLock()
+	defer c.sess.mu.RUnlock()
+	return c.sess.serviceTicket
+}
+
+func (c *Client) setTicket(t string) {
+	c.sess.mu.Lock()
+	c.sess.serviceTicket = t
+	c.sess.mu.Unlock()
+}
+
 // Login est a session with the Ruckus SZ Controller
 func (c *Client) Login() error {
 	// Create our Auth JSON Object|Convert to Reader for POST REQ
@@ -54,17 +87,23 @@ func (c *Client) Login() error {
 		return fmt.Errorf("failed to create a new request: %v", err)
 	}
 	req.Header.Add("Content-Type", "application/json;charset=UTF-8")
-	res, err := c.http.Do(req)
+	res, err := c.doRetry(req, true)
 	if err != nil {
 		return fmt.Errorf("failed to login: %v", err)
 	}
 	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newRksError(res)
+	}
 	// Auth RESP returns an JSON Object with serviceTicket Field
 	auth := struct {
 		Ticket string `json:"serviceTicket"`
 	}{}
 	json.NewDecoder(res.Body).Decode(&auth)
-	c.serviceTicket = auth.Ticket
+	if auth.Ticket == "" {
+		return fmt.Errorf("failed to login: no service ticket returned")
+	}
+	c.setTicket(auth.Ticket)
 	return nil
 }
 
@@ -75,20 +114,20 @@ func (c *Client) Logout() error {
 		return fmt.Errorf("failed to create a new request: %v", err)
 	}
 	q := req.URL.Query()
-	q.Add("serviceTicket", c.serviceTicket)
+	q.Add("serviceTicket", c.ticket())
 	req.URL.RawQuery = q.Encode()
 
-	_, err = c.http.Do(req)
+	_, err = c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to logout: %v", err)
 	}
-	c.serviceTicket = ""
+	c.setTicket("")
 	return nil
 }
 
 // GetZones retrieves a Paginated List of Zones
 func (c *Client) GetZones(o RksOptions) (RksCommonRes, error) {
-	if c.serviceTicket == "" {
+	if c.ticket() == "" {
 		return RksCommonRes{}, fmt.Errorf(loginErr)
 	}
 	req, err := c.genGetReq("/rkszones")
@@ -98,7 +137,7 @@ func (c *Client) GetZones(o RksOptions) (RksCommonRes, error) {
 	// Update the Request
 	c.addQS(req, o)
 
-	res, err := c.http.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return RksCommonRes{}, fmt.Errorf("failed to get resp: %v", err)
 	}
@@ -111,7 +150,7 @@ func (c *Client) GetZones(o RksOptions) (RksCommonRes, error) {
 
 // GetZone retrieve Zone Configuration from Rks Controller
 func (c *Client) GetZone(id string) (RksZone, error) {
-	if c.serviceTicket == "" {
+	if c.ticket() == "" {
 		return RksZone{}, fmt.Errorf(loginErr)
 	}
 	req, err := c.genGetReq(fmt.Sprintf("/rkszones/%s", id))
@@ -119,7 +158,7 @@ func (c *Client) GetZone(id string) (RksZone, error) {
 		return RksZone{}, err
 	}
 	c.addQS(req, RksOptions{})
-	res, err := c.http.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return RksZone{}, fmt.Errorf("request failed: %v", err)
 	}
@@ -131,7 +170,7 @@ func (c *Client) GetZone(id string) (RksZone, error) {
 
 // GetSysSum retrieves system summary information from the Ruckus Controller
 func (c *Client) GetSysSum(o RksOptions) (RksSysSumRes, error) {
-	if c.serviceTicket == "" {
+	if c.ticket() == "" {
 		return RksSysSumRes{}, fmt.Errorf(loginErr)
 	}
 	req, err := c.genGetReq("/controller")
@@ -140,7 +179,7 @@ func (c *Client) GetSysSum(o RksOptions) (RksSysSumRes, error) {
 	}
 	c.addQS(req, o)
 
-	res, err := c.http.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return RksSysSumRes{}, fmt.Errorf("failed to get resp: %v", err)
 	}
@@ -160,7 +199,7 @@ func (c *Client) genGetReq(url string) (*http.Request, error) {
 
 func (c *Client) addQS(r *http.Request, o RksOptions) {
 	q := r.URL.Query()
-	q.Add("serviceTicket", c.serviceTicket)
+	q.Add("serviceTicket", c.ticket())
 	if o.Index != "" {
 		q.Add("index", o.Index)
 	}
@@ -169,10 +208,64 @@ func (c *Client) addQS(r *http.Request, o RksOptions) {
 	}
 	if o.DomainID != "" {
 		q.Add("domainId", o.DomainID)
+	} else if c.domainID != "" {
+		q.Add("domainId", c.domainID)
 	}
 	r.URL.RawQuery = q.Encode()
 }
 
+// send issues a Request with an optional JSON body decoding the JSON
+// response into out (when not nil); a non 2xx response is an *RksError
+func (c *Client) send(method, ep string, o RksOptions, body, out interface{}) error {
+	if body == nil {
+		return c.sendBody(method, ep, o, "", nil, out)
+	}
+	jdata, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %v", err)
+	}
+	return c.sendBody(method, ep, o, "application/json;charset=UTF-8", bytes.NewReader(jdata), out)
+}
+
+// sendBody is send with a body of contentType already encoded (ie a file upload)
+func (c *Client) sendBody(method, ep string, o RksOptions, contentType string, body io.Reader, out interface{}) error {
+	if c.ticket() == "" {
+		return fmt.Errorf(loginErr)
+	}
+	req, err := http.NewRequest(method, c.BaseURL+ep, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	if contentType != "" {
+		req.Header.Add("Content-Type", contentType)
+	}
+	c.addQS(req, o)
+	res, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get resp: %v", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newRksError(res)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to decode resp: %v", err)
+	}
+	return nil
+}
+
+func newRksError(res *http.Response) error {
+	rksErr := &RksError{StatusCode: res.StatusCode}
+	d, _ := ioutil.ReadAll(res.Body)
+	if err := json.Unmarshal(d, rksErr); err != nil || rksErr.Message == "" {
+		rksErr.Message = strings.TrimSpace(string(d))
+	}
+	return rksErr
+}
+
 // RksZone properties and fields of a Ruckus Controller Zone
 type RksZone struct {
 	ID          string `json:"id"`
@@ -182,112 +275,107 @@ type RksZone struct {
 	CountryCode string `json:"countryCode"`
 	Version     string `json:"version"`
 	Timezone    struct {
-		SystemTimezone     string      `json:"systemTimezone"`
-		CustomizedTimezone interface{} `json:"customizedTimezone"`
+		SystemTimezone     string             `json:"systemTimezone"`
+		CustomizedTimezone *RksCustomTimezone `json:"customizedTimezone"`
 	} `json:"timezone"`
-	IPMode                   string      `json:"ipMode"`
-	Ipv6TrafficFilterEnabled interface{} `json:"ipv6TrafficFilterEnabled"`
+	IPMode                   string `json:"ipMode"`
+	Ipv6TrafficFilterEnabled *bool  `json:"ipv6TrafficFilterEnabled"`
 	Login                    struct {
 		ApLoginName     string `json:"apLoginName"`
 		ApLoginPassword string `json:"apLoginPassword"`
 	} `json:"login"`
-	Mesh                       interface{} `json:"mesh"`
-	DfsChannelEnabled          bool        `json:"dfsChannelEnabled"`
-	CbandChannelEnabled        bool        `json:"cbandChannelEnabled"`
-	CbandChannelLicenseEnabled bool        `json:"cbandChannelLicenseEnabled"`
-	Channel144Enabled          bool        `json:"channel144Enabled"`
+	Mesh                       *RksZoneMesh `json:"mesh"`
+	DfsChannelEnabled          bool         `json:"dfsChannelEnabled"`
+	CbandChannelEnabled        bool         `json:"cbandChannelEnabled"`
+	CbandChannelLicenseEnabled bool         `json:"cbandChannelLicenseEnabled"`
+	Channel144Enabled          bool         `json:"channel144Enabled"`
 	Wifi24                     struct {
-		AutoCellSizing        interface{} `json:"autoCellSizing"`
-		TxPower               string      `json:"txPower"`
-		ChannelWidth          int         `json:"channelWidth"`
-		Channel               int         `json:"channel"`
-		ChannelRange          []int       `json:"channelRange"`
-		AvailableChannelRange []int       `json:"availableChannelRange"`
+		AutoCellSizing        *bool  `json:"autoCellSizing"`
+		TxPower               string `json:"txPower"`
+		ChannelWidth          int    `json:"channelWidth"`
+		Channel               int    `json:"channel"`
+		ChannelRange          []int  `json:"channelRange"`
+		AvailableChannelRange []int  `json:"availableChannelRange"`
 	} `json:"wifi24"`
 	Wifi50 struct {
-		AutoCellSizing               interface{} `json:"autoCellSizing"`
-		TxPower                      string      `json:"txPower"`
-		ChannelWidth                 int         `json:"channelWidth"`
-		IndoorChannel                int         `json:"indoorChannel"`
-		OutdoorChannel               int         `json:"outdoorChannel"`
-		IndoorSecondaryChannel       interface{} `json:"indoorSecondaryChannel"`
-		OutdoorSecondaryChannel      interface{} `json:"outdoorSecondaryChannel"`
-		IndoorChannelRange           []int       `json:"indoorChannelRange"`
-		OutdoorChannelRange          []int       `json:"outdoorChannelRange"`
-		AvailableIndoorChannelRange  []int       `json:"availableIndoorChannelRange"`
-		AvailableOutdoorChannelRange []int       `json:"availableOutdoorChannelRange"`
+		AutoCellSizing               *bool  `json:"autoCellSizing"`
+		TxPower                      string `json:"txPower"`
+		ChannelWidth                 int    `json:"channelWidth"`
+		IndoorChannel                int    `json:"indoorChannel"`
+		OutdoorChannel               int    `json:"outdoorChannel"`
+		IndoorSecondaryChannel       *int   `json:"indoorSecondaryChannel"`
+		OutdoorSecondaryChannel      *int   `json:"outdoorSecondaryChannel"`
+		IndoorChannelRange           []int  `json:"indoorChannelRange"`
+		OutdoorChannelRange          []int  `json:"outdoorChannelRange"`
+		AvailableIndoorChannelRange  []int  `json:"availableIndoorChannelRange"`
+		AvailableOutdoorChannelRange []int  `json:"availableOutdoorChannelRange"`
 	} `json:"wifi50"`
-	ProtectionMode24         string      `json:"protectionMode24"`
-	Syslog                   interface{} `json:"syslog"`
-	SmartMonitor             interface{} `json:"smartMonitor"`
-	ClientAdmissionControl24 interface{} `json:"clientAdmissionControl24"`
-	ClientAdmissionControl50 interface{} `json:"clientAdmissionControl50"`
-	ChannelModeEnabled       bool        `json:"channelModeEnabled"`
-	TunnelType               string      `json:"tunnelType"`
-	TunnelProfile            struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-	} `json:"tunnelProfile"`
-	RuckusGreTunnelProfile struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-	} `json:"ruckusGreTunnelProfile"`
-	SoftGreTunnelProflies interface{} `json:"softGreTunnelProflies"`
-	IpsecProfiles         interface{} `json:"ipsecProfiles"`
-	IpsecTunnelMode       interface{} `json:"ipsecTunnelMode"`
-	BackgroundScanning24  struct {
+	ProtectionMode24         string                     `json:"protectionMode24"`
+	Syslog                   *RksZoneSyslog             `json:"syslog"`
+	SmartMonitor             *RksSmartMonitor           `json:"smartMonitor"`
+	ClientAdmissionControl24 *RksClientAdmissionControl `json:"clientAdmissionControl24"`
+	ClientAdmissionControl50 *RksClientAdmissionControl `json:"clientAdmissionControl50"`
+	ChannelModeEnabled       bool                       `json:"channelModeEnabled"`
+	TunnelType               string                     `json:"tunnelType"`
+	TunnelProfile            RksObject                  `json:"tunnelProfile"`
+	RuckusGreTunnelProfile   RksObject                  `json:"ruckusGreTunnelProfile"`
+	SoftGreTunnelProflies    []RksZoneSoftGre           `json:"softGreTunnelProflies"`
+	IpsecProfiles            []RksObject                `json:"ipsecProfiles"`
+	// DISABLE|SOFT_GRE|RUCKUS_GRE
+	IpsecTunnelMode      string `json:"ipsecTunnelMode"`
+	BackgroundScanning24 struct {
 		FrequencyInSec int `json:"frequencyInSec"`
 	} `json:"backgroundScanning24"`
 	BackgroundScanning50 struct {
 		FrequencyInSec int `json:"frequencyInSec"`
 	} `json:"backgroundScanning50"`
-	ClientLoadBalancing24 interface{} `json:"clientLoadBalancing24"`
-	ClientLoadBalancing50 interface{} `json:"clientLoadBalancing50"`
+	ClientLoadBalancing24 *RksClientLoadBalancing `json:"clientLoadBalancing24"`
+	ClientLoadBalancing50 *RksClientLoadBalancing `json:"clientLoadBalancing50"`
 	BandBalancing         struct {
 		Mode             string `json:"mode"`
 		Wifi24Percentage int    `json:"wifi24Percentage"`
 	} `json:"bandBalancing"`
 	LoadBalancingMethod string `json:"loadBalancingMethod"`
 	Rogue               struct {
-		ReportType        string      `json:"reportType"`
-		MaliciousTypes    interface{} `json:"maliciousTypes"`
-		ProtectionEnabled bool        `json:"protectionEnabled"`
+		ReportType        string   `json:"reportType"`
+		MaliciousTypes    []string `json:"maliciousTypes"`
+		ProtectionEnabled bool     `json:"protectionEnabled"`
 	} `json:"rogue"`
-	LocationBasedService interface{} `json:"locationBasedService"`
+	LocationBasedService *RksObject `json:"locationBasedService"`
 	ApRebootTimeout      struct {
 		GatewayLossTimeoutInSec int `json:"gatewayLossTimeoutInSec"`
 		ServerLossTimeoutInSec  int `json:"serverLossTimeoutInSec"`
 	} `json:"apRebootTimeout"`
 	Location                          string      `json:"location"`
 	LocationAdditionalInfo            string      `json:"locationAdditionalInfo"`
-	Latitude                          interface{} `json:"latitude"`
-	Longitude                         interface{} `json:"longitude"`
+	Latitude                          *Coordinate `json:"latitude"`
+	Longitude                         *Coordinate `json:"longitude"`
 	VlanOverlappingEnabled            bool        `json:"vlanOverlappingEnabled"`
-	NodeAffinityProfile               interface{} `json:"nodeAffinityProfile"`
+	NodeAffinityProfile               *RksObject  `json:"nodeAffinityProfile"`
 	ZoneAffinityProfileID             string      `json:"zoneAffinityProfileId"`
 	EnforcePriorityZoneAffinityEnable bool        `json:"enforcePriorityZoneAffinityEnable"`
 	AwsVenue                          string      `json:"awsVenue"`
-	VenueProfile                      interface{} `json:"venueProfile"`
-	IpsecProfile                      interface{} `json:"ipsecProfile"`
+	VenueProfile                      *RksObject  `json:"venueProfile"`
+	IpsecProfile                      *RksObject  `json:"ipsecProfile"`
 	BonjourFencingPolicyEnabled       bool        `json:"bonjourFencingPolicyEnabled"`
 	DhcpSiteConfig                    struct {
-		SiteEnabled    bool        `json:"siteEnabled"`
-		DwpdEnabled    interface{} `json:"dwpdEnabled"`
-		ManualSelect   interface{} `json:"manualSelect"`
-		SiteMode       interface{} `json:"siteMode"`
-		SiteProfileIds interface{} `json:"siteProfileIds"`
-		SiteAps        interface{} `json:"siteAps"`
-		Eth0ProfileID  interface{} `json:"eth0ProfileId"`
-		Eth1ProfileID  interface{} `json:"eth1ProfileId"`
+		SiteEnabled    bool            `json:"siteEnabled"`
+		DwpdEnabled    *bool           `json:"dwpdEnabled"`
+		ManualSelect   *bool           `json:"manualSelect"`
+		SiteMode       string          `json:"siteMode"`
+		SiteProfileIds []int           `json:"siteProfileIds"`
+		SiteAps        []RksDhcpSiteAp `json:"siteAps"`
+		Eth0ProfileID  *int            `json:"eth0ProfileId"`
+		Eth1ProfileID  *int            `json:"eth1ProfileId"`
 	} `json:"dhcpSiteConfig"`
-	BonjourFencingPolicy   interface{} `json:"bonjourFencingPolicy"`
+	BonjourFencingPolicy   *RksObject `json:"bonjourFencingPolicy"`
 	AutoChannelSelection24 struct {
-		ChannelSelectMode string      `json:"channelSelectMode"`
-		ChannelFlyMtbc    interface{} `json:"channelFlyMtbc"`
+		ChannelSelectMode string `json:"channelSelectMode"`
+		ChannelFlyMtbc    *int   `json:"channelFlyMtbc"`
 	} `json:"autoChannelSelection24"`
 	AutoChannelSelection50 struct {
-		ChannelSelectMode string      `json:"channelSelectMode"`
-		ChannelFlyMtbc    interface{} `json:"channelFlyMtbc"`
+		ChannelSelectMode string `json:"channelSelectMode"`
+		ChannelFlyMtbc    *int   `json:"channelFlyMtbc"`
 	} `json:"autoChannelSelection50"`
 	ChannelEvaluationInterval int `json:"channelEvaluationInterval"`
 	ApMgmtVlan                struct {
@@ -298,8 +386,8 @@ type RksZone struct {
 		PingEnabled bool `json:"pingEnabled"`
 	} `json:"apLatencyInterval"`
 	Altitude struct {
-		AltitudeUnit  string      `json:"altitudeUnit"`
-		AltitudeValue interface{} `json:"altitudeValue"`
+		AltitudeUnit  string `json:"altitudeUnit"`
+		AltitudeValue *int   `json:"altitudeValue"`
 	} `json:"altitude"`
 	RecoverySsid struct {
 		RecoverySsidEnabled bool `json:"recoverySsidEnabled"`
@@ -309,22 +397,22 @@ type RksZone struct {
 	DosBarringThreshold   int `json:"dosBarringThreshold"`
 	DosBarringCheckPeriod int `json:"dosBarringCheckPeriod"`
 	SnmpAgent             struct {
-		ApSnmpEnabled bool          `json:"apSnmpEnabled"`
-		SnmpV2Agent   []interface{} `json:"snmpV2Agent"`
-		SnmpV3Agent   []interface{} `json:"snmpV3Agent"`
+		ApSnmpEnabled bool             `json:"apSnmpEnabled"`
+		SnmpV2Agent   []RksSnmpV2Agent `json:"snmpV2Agent"`
+		SnmpV3Agent   []RksSnmpV3Agent `json:"snmpV3Agent"`
 	} `json:"snmpAgent"`
-	ClusterRedundancyEnabled                   bool        `json:"clusterRedundancyEnabled"`
-	AaaAffinityEnabled                         bool        `json:"aaaAffinityEnabled"`
-	RogueApReportThreshold                     int         `json:"rogueApReportThreshold"`
-	RogueApAggressivenessMode                  int         `json:"rogueApAggressivenessMode"`
-	RogueApJammingDetection                    bool        `json:"rogueApJammingDetection"`
-	RogueApJammingThreshold                    interface{} `json:"rogueApJammingThreshold"`
-	DirectedMulticastFromWiredClientEnabled    bool        `json:"directedMulticastFromWiredClientEnabled"`
-	DirectedMulticastFromWirelessClientEnabled bool        `json:"directedMulticastFromWirelessClientEnabled"`
-	DirectedMulticastFromNetworkEnabled        bool        `json:"directedMulticastFromNetworkEnabled"`
-	HealthCheckSitesEnabled                    bool        `json:"healthCheckSitesEnabled"`
-	HealthCheckSites                           []string    `json:"healthCheckSites"`
-	SSHTunnelEncryption                        string      `json:"sshTunnelEncryption"`
+	ClusterRedundancyEnabled                   bool     `json:"clusterRedundancyEnabled"`
+	AaaAffinityEnabled                         bool     `json:"aaaAffinityEnabled"`
+	RogueApReportThreshold                     int      `json:"rogueApReportThreshold"`
+	RogueApAggressivenessMode                  int      `json:"rogueApAggressivenessMode"`
+	RogueApJammingDetection                    bool     `json:"rogueApJammingDetection"`
+	RogueApJammingThreshold                    *int     `json:"rogueApJammingThreshold"`
+	DirectedMulticastFromWiredClientEnabled    bool     `json:"directedMulticastFromWiredClientEnabled"`
+	DirectedMulticastFromWirelessClientEnabled bool     `json:"directedMulticastFromWirelessClientEnabled"`
+	DirectedMulticastFromNetworkEnabled        bool     `json:"directedMulticastFromNetworkEnabled"`
+	HealthCheckSitesEnabled                    bool     `json:"healthCheckSitesEnabled"`
+	HealthCheckSites                           []string `json:"healthCheckSites"`
+	SSHTunnelEncryption                        string   `json:"sshTunnelEncryption"`
 	LteBandLockChannels                        []struct {
 		SimCardID int    `json:"simCardId"`
 		Type      string `json:"type"`
@@ -333,4 +421,13 @@ type RksZone struct {
 	} `json:"lteBandLockChannels"`
 	ApHccdEnabled bool `json:"apHccdEnabled"`
 	ApHccdPersist bool `json:"apHccdPersist"`
+
+	// the top level fields of the document the Zone was retrieved as it does
+	// not model (ie added by newer Controller releases); set, changed or
+	// removed they are encoded so
+	Unknown map[string]json.RawMessage `json:"-"`
+
+	// the document the Zone was retrieved as; the fields not modelled above
+	// are encoded back from it
+	rawDoc
 }
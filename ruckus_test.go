@@ -0,0 +1,128 @@
+package ruckus
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func TestLoginRejected(t *testing.T) {
+	f, c := newFakeController(t)
+	f.onStatus("POST /serviceTicket", http.StatusUnauthorized, `{"errorCode":201,"errorType":"Not logged in","message":"bad credentials"}`)
+	c.setTicket("")
+
+	err := c.Login()
+	rksErr, ok := err.(*RksError)
+	if !ok || rksErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Login() = %v; want an RksError 401", err)
+	}
+	if c.ticket() != "" {
+		t.Errorf("a ticket was kept after a rejected login")
+	}
+}
+
+func TestLoginWithoutTicket(t *testing.T) {
+	f, c := newFakeController(t)
+	f.on("POST /serviceTicket", `{}`)
+
+	if err := c.Login(); err == nil {
+		t.Fatalf("Login() succeeded without a service ticket")
+	}
+}
+
+// fakeController a Controller answering from canned responses
+// and recording the requests it receives
+type fakeController struct {
+	mu        sync.Mutex
+	responses map[string][]fakeResponse
+	served    map[string]int
+	requests  []fakeRequest
+}
+
+type fakeResponse struct {
+	status int
+	body   string
+}
+
+// fakeRequest a request received by a fakeController
+type fakeRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   string
+}
+
+// newFakeController a fakeController and a logged in Client talking to it
+func newFakeController(t *testing.T) (*fakeController, *Client) {
+	f := &fakeController{
+		responses: make(map[string][]fakeResponse),
+		served:    make(map[string]int),
+	}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	c := New("9_0", "localhost", "admin", "admin", true)
+	c.BaseURL = srv.URL
+	c.setTicket("ST-test")
+	return f, c
+}
+
+// on answers the requests for route with bodies in turn, the last one
+// once they are all served. route is "METHOD /path" or "/path" for any
+// method; "?domainId=" and an ID may follow the path to only answer the
+// requests of that Domain
+func (f *fakeController) on(route string, bodies ...string) {
+	for _, b := range bodies {
+		f.onStatus(route, http.StatusOK, b)
+	}
+}
+
+// onStatus adds a response of status to those of route (see on)
+func (f *fakeController) onStatus(route string, status int, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[route] = append(f.responses[route], fakeResponse{status, body})
+}
+
+// received the requests received for route (see on), all when route is ""
+func (f *fakeController) received(route string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var reqs []fakeRequest
+	for _, r := range f.requests {
+		if route == "" || route == r.Method+" "+r.Path || route == r.Path {
+			reqs = append(reqs, r)
+		}
+	}
+	return reqs
+}
+
+func (f *fakeController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, fakeRequest{r.Method, r.URL.Path, r.URL.Query(), string(body)})
+	var routes []string
+	if d := r.URL.Query().Get("domainId"); d != "" {
+		routes = append(routes, r.Method+" "+r.URL.Path+"?domainId="+d, r.URL.Path+"?domainId="+d)
+	}
+	routes = append(routes, r.Method+" "+r.URL.Path, r.URL.Path)
+	for _, route := range routes {
+		res, ok := f.responses[route]
+		if !ok {
+			continue
+		}
+		n := f.served[route]
+		f.served[route]++
+		if n >= len(res) {
+			n = len(res) - 1
+		}
+		w.WriteHeader(res[n].status)
+		w.Write([]byte(res[n].body))
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte(`{"message":"no such resource"}`))
+}
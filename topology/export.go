@@ -0,0 +1,196 @@
+package topology
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WriteDOT writes the Graph in Graphviz DOT format
+// APs are placed in a cluster per Zone with a nested cluster per AP Group
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "graph topology {")
+	fmt.Fprintln(bw, "\tnode [fontsize=10];")
+	for _, n := range g.Nodes {
+		if n.Kind == KindAP {
+			continue
+		}
+		fmt.Fprintf(bw, "\t%s;\n", dotNode(n))
+	}
+	nodes := g.nodeIndex()
+	groups := g.Groups()
+	zi := 0
+	for _, zone := range sortedKeys(groups) {
+		fmt.Fprintf(bw, "\tsubgraph cluster_%d {\n", zi)
+		fmt.Fprintf(bw, "\t\tlabel=%s;\n", strconv.Quote("zone: "+zone))
+		gi := 0
+		for _, group := range sortedKeys(groups[zone]) {
+			fmt.Fprintf(bw, "\t\tsubgraph cluster_%d_%d {\n", zi, gi)
+			fmt.Fprintf(bw, "\t\t\tlabel=%s;\n", strconv.Quote("group: "+group))
+			for _, id := range groups[zone][group] {
+				fmt.Fprintf(bw, "\t\t\t%s;\n", dotNode(nodes[id]))
+			}
+			fmt.Fprintln(bw, "\t\t}")
+			gi++
+		}
+		fmt.Fprintln(bw, "\t}")
+		zi++
+	}
+	for _, e := range g.Edges {
+		attrs := ""
+		if e.LocalIntf != "" {
+			attrs = fmt.Sprintf(" [label=%s]", strconv.Quote(e.LocalIntf))
+		}
+		fmt.Fprintf(bw, "\t%s -- %s%s;\n", strconv.Quote(e.Source), strconv.Quote(e.Target), attrs)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func dotNode(n Node) string {
+	label := n.Label
+	if n.IPAddr != "" && n.Kind != KindPort {
+		label += "\n" + n.IPAddr
+	}
+	shape := map[NodeKind]string{
+		KindSwitch: "box",
+		KindDevice: "box",
+		KindPort:   "point",
+		KindAP:     "ellipse",
+	}[n.Kind]
+	attrs := fmt.Sprintf("label=%s, shape=%s", strconv.Quote(label), shape)
+	if n.Kind == KindPort {
+		attrs = fmt.Sprintf("xlabel=%s, shape=%s", strconv.Quote(label), shape)
+	}
+	if n.Inconsistent {
+		attrs += ", color=red"
+	}
+	return fmt.Sprintf("%s [%s]", strconv.Quote(n.ID), attrs)
+}
+
+// graphML attribute keys: id, type
+var graphMLKeys = [][2]string{
+	{"kind", "string"},
+	{"label", "string"},
+	{"ip", "string"},
+	{"zone", "string"},
+	{"group", "string"},
+	{"parent", "string"},
+	{"inconsistent", "boolean"},
+	{"localIntf", "string"},
+}
+
+// WriteGraphML writes the Graph in GraphML format
+// Zone and AP Group are exposed as Node data attributes
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, xml.Header+`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	for _, k := range graphMLKeys {
+		scope := "node"
+		if k[0] == "localIntf" {
+			scope = "edge"
+		}
+		fmt.Fprintf(bw, "  <key id=%q for=%q attr.name=%q attr.type=%q/>\n", k[0], scope, k[0], k[1])
+	}
+	fmt.Fprintln(bw, `  <graph id="topology" edgedefault="undirected">`)
+	for _, n := range g.Nodes {
+		fmt.Fprintf(bw, "    <node id=\"%s\">\n", xmlEscape(n.ID))
+		data := [][2]string{
+			{"kind", string(n.Kind)},
+			{"label", n.Label},
+			{"ip", n.IPAddr},
+			{"zone", n.Zone},
+			{"group", n.Group},
+			{"parent", n.Parent},
+		}
+		if n.Inconsistent {
+			data = append(data, [2]string{"inconsistent", "true"})
+		}
+		for _, d := range data {
+			if d[1] == "" {
+				continue
+			}
+			fmt.Fprintf(bw, "      <data key=%q>%s</data>\n", d[0], xmlEscape(d[1]))
+		}
+		fmt.Fprintln(bw, "    </node>")
+	}
+	for i, e := range g.Edges {
+		fmt.Fprintf(bw, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">", i, xmlEscape(e.Source), xmlEscape(e.Target))
+		if e.LocalIntf != "" {
+			fmt.Fprintf(bw, "<data key=\"localIntf\">%s</data>", xmlEscape(e.LocalIntf))
+		}
+		fmt.Fprintln(bw, "</edge>")
+	}
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</graphml>")
+	return bw.Flush()
+}
+
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// nodeLink the JSON node-link document (as read by d3|networkx)
+type nodeLink struct {
+	Directed   bool                           `json:"directed"`
+	Multigraph bool                           `json:"multigraph"`
+	Nodes      []Node                         `json:"nodes"`
+	Links      []Edge                         `json:"links"`
+	Groups     map[string]map[string][]string `json:"groups"`
+	Warnings   []string                       `json:"warnings"`
+}
+
+// WriteJSON writes the Graph in JSON node-link format
+// Groups maps Zone to AP Group to the IDs of its AP Nodes
+func (g *Graph) WriteJSON(w io.Writer) error {
+	doc := nodeLink{
+		Nodes:    g.Nodes,
+		Links:    g.Edges,
+		Groups:   g.Groups(),
+		Warnings: g.Warnings,
+	}
+	if doc.Nodes == nil {
+		doc.Nodes = []Node{}
+	}
+	if doc.Links == nil {
+		doc.Links = []Edge{}
+	}
+	if doc.Warnings == nil {
+		doc.Warnings = []string{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&doc)
+}
+
+func (g *Graph) nodeIndex() map[string]Node {
+	idx := make(map[string]Node, len(g.Nodes))
+	for _, n := range g.Nodes {
+		idx[n.ID] = n
+	}
+	return idx
+}
+
+func sortedKeys(m interface{}) []string {
+	var ks []string
+	switch m := m.(type) {
+	case map[string]map[string][]string:
+		for k := range m {
+			ks = append(ks, k)
+		}
+	case map[string][]string:
+		for k := range m {
+			ks = append(ks, k)
+		}
+	}
+	sort.Strings(ks)
+	return ks
+}
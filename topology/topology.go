@@ -0,0 +1,255 @@
+// Package topology builds the edge Network Topology of a SmartZone
+// deployment from its APs and their LLDP Neighbors
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ApogeeNetworking/ruckus"
+)
+
+// NodeKind the kind of Device a Node represents
+type NodeKind string
+
+// Kinds of Nodes in a Graph
+const (
+	// a Neighbor advertising the Bridge or Router capability
+	KindSwitch NodeKind = "switch"
+	// a Neighbor that is neither Switch nor AP (ie phone daisy-chained to an AP)
+	KindDevice NodeKind = "device"
+	// a Port of a Switch|Device
+	KindPort NodeKind = "port"
+	// a Ruckus AP
+	KindAP NodeKind = "ap"
+)
+
+// Node a Device or Port in the Topology
+type Node struct {
+	ID     string   `json:"id"`
+	Kind   NodeKind `json:"kind"`
+	Label  string   `json:"label"`
+	IPAddr string   `json:"ip,omitempty"`
+	// the Zone|AP Group the Node belongs to (APs only)
+	Zone  string `json:"zone,omitempty"`
+	Group string `json:"group,omitempty"`
+	// the Node Owning a Port
+	Parent string `json:"parent,omitempty"`
+	// set when the Switch was seen with inconsistent Hostname/IP pairs
+	Inconsistent bool `json:"inconsistent,omitempty"`
+}
+
+// Edge a Link between two Nodes
+type Edge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// the AP Interface the Link was seen on (port to AP Edges only)
+	LocalIntf string `json:"localIntf,omitempty"`
+}
+
+// Graph the Topology of Switches, Ports and APs
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+	// human readable description of every inconsistency found
+	Warnings []string
+}
+
+// Build creates the Graph of aps from the LLDP Neighbors of each AP
+// neighbors is keyed by AP MAC Address (as in RksAp.MacAddr)
+func Build(aps []ruckus.RksAp, neighbors map[string][]ruckus.ApLldpNeighbor) *Graph {
+	b := builder{
+		nodes:     make(map[string]*Node),
+		edges:     make(map[Edge]bool),
+		hostIPs:   make(map[string]map[string]bool),
+		ipHosts:   make(map[string]map[string]bool),
+		nameNodes: make(map[string]map[string]bool),
+		ipNodes:   make(map[string]map[string]bool),
+	}
+	for _, ap := range aps {
+		apID := "ap:" + strings.ToLower(ap.MacAddr)
+		label := ap.ApName
+		if label == "" {
+			label = ap.MacAddr
+		}
+		b.addNode(Node{
+			ID:     apID,
+			Kind:   KindAP,
+			Label:  label,
+			IPAddr: ap.IPAddr,
+			Zone:   ap.ZoneName,
+			Group:  ap.GroupName,
+		})
+		for i, n := range neighbors[ap.MacAddr] {
+			// where the Neighbor was seen; keys what it does not identify itself
+			seenOn := fmt.Sprintf("%s/%s", apID, n.LocalIntf)
+			if n.LocalIntf == "" {
+				seenOn = fmt.Sprintf("%s/%d", apID, i)
+			}
+			devID := b.addNeighbor(n, seenOn)
+			portLabel := n.PortID
+			if portLabel == "" {
+				portLabel = n.PortDesc
+			}
+			portID := devID + "|" + portLabel
+			if portLabel == "" {
+				portID = devID + "|" + seenOn
+			}
+			b.addNode(Node{ID: portID, Kind: KindPort, Label: portLabel, Parent: devID})
+			b.edges[Edge{Source: devID, Target: portID}] = true
+			b.edges[Edge{Source: portID, Target: apID, LocalIntf: n.LocalIntf}] = true
+		}
+	}
+	return b.graph()
+}
+
+type builder struct {
+	nodes map[string]*Node
+	edges map[Edge]bool
+	// Hostname to IPs and IP to Hostnames seen across all Neighbors
+	hostIPs map[string]map[string]bool
+	ipHosts map[string]map[string]bool
+	// Hostname|IP to the Node IDs it was seen on
+	nameNodes map[string]map[string]bool
+	ipNodes   map[string]map[string]bool
+	// where the Neighbors without any identifier were seen
+	anonymous []string
+}
+
+func (b *builder) addNode(n Node) {
+	if _, ok := b.nodes[n.ID]; ok {
+		return
+	}
+	b.nodes[n.ID] = &n
+}
+
+// addNeighbor adds the Switch|Device behind an LLDP Neighbor returning its ID
+// a Neighbor advertising no Chassis ID, Hostname or IP cannot be told apart
+// from any other: it is keyed by seenOn, the AP Interface it was seen on
+func (b *builder) addNeighbor(n ruckus.ApLldpNeighbor, seenOn string) string {
+	var id string
+	switch {
+	case n.ChassisID != "":
+		id = "sw:" + strings.ToLower(n.ChassisID)
+	case n.SysName != "":
+		id = "sw:" + strings.ToLower(n.SysName)
+	case n.MgmtIP != "":
+		id = "sw:" + n.MgmtIP
+	default:
+		id = "unknown:" + seenOn
+		b.anonymous = append(b.anonymous, seenOn)
+	}
+	kind := KindDevice
+	for _, c := range n.Capabilities {
+		if c.Name == "Bridge" || c.Name == "Router" {
+			kind = KindSwitch
+		}
+	}
+	label := n.SysName
+	if label == "" {
+		label = n.ChassisID
+	}
+	if label == "" {
+		label = n.MgmtIP
+	}
+	if label == "" {
+		label = "unknown"
+	}
+	b.addNode(Node{ID: id, Kind: kind, Label: label, IPAddr: n.MgmtIP})
+	if n.SysName != "" && n.MgmtIP != "" {
+		name := strings.ToLower(n.SysName)
+		addTo(b.hostIPs, name, n.MgmtIP)
+		addTo(b.ipHosts, n.MgmtIP, name)
+		addTo(b.nameNodes, name, id)
+		addTo(b.ipNodes, n.MgmtIP, id)
+	}
+	return id
+}
+
+func addTo(m map[string]map[string]bool, k, v string) {
+	if m[k] == nil {
+		m[k] = make(map[string]bool)
+	}
+	m[k][v] = true
+}
+
+func (b *builder) graph() *Graph {
+	g := &Graph{}
+	for host, ips := range b.hostIPs {
+		if len(ips) > 1 {
+			g.Warnings = append(g.Warnings, fmt.Sprintf(
+				"switch %s seen with multiple IPs: %s", host, strings.Join(keys(ips), ", "),
+			))
+			b.flag(b.nameNodes[host])
+		}
+	}
+	for ip, hosts := range b.ipHosts {
+		if len(hosts) > 1 {
+			g.Warnings = append(g.Warnings, fmt.Sprintf(
+				"IP %s seen with multiple switch hostnames: %s", ip, strings.Join(keys(hosts), ", "),
+			))
+			b.flag(b.ipNodes[ip])
+		}
+	}
+	for _, seenOn := range b.anonymous {
+		g.Warnings = append(g.Warnings, fmt.Sprintf(
+			"neighbor of %s advertises no chassis ID, hostname or IP", seenOn,
+		))
+	}
+	sort.Strings(g.Warnings)
+	for _, id := range keys(nodeSet(b.nodes)) {
+		g.Nodes = append(g.Nodes, *b.nodes[id])
+	}
+	for e := range b.edges {
+		g.Edges = append(g.Edges, e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].Source != g.Edges[j].Source {
+			return g.Edges[i].Source < g.Edges[j].Source
+		}
+		if g.Edges[i].Target != g.Edges[j].Target {
+			return g.Edges[i].Target < g.Edges[j].Target
+		}
+		return g.Edges[i].LocalIntf < g.Edges[j].LocalIntf
+	})
+	return g
+}
+
+func (b *builder) flag(ids map[string]bool) {
+	for id := range ids {
+		b.nodes[id].Inconsistent = true
+	}
+}
+
+func nodeSet(nodes map[string]*Node) map[string]bool {
+	set := make(map[string]bool, len(nodes))
+	for id := range nodes {
+		set[id] = true
+	}
+	return set
+}
+
+func keys(set map[string]bool) []string {
+	var ks []string
+	for k := range set {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+// Groups returns the AP Node IDs of the Graph grouped by Zone then AP Group
+func (g *Graph) Groups() map[string]map[string][]string {
+	groups := make(map[string]map[string][]string)
+	for _, n := range g.Nodes {
+		if n.Kind != KindAP {
+			continue
+		}
+		if groups[n.Zone] == nil {
+			groups[n.Zone] = make(map[string][]string)
+		}
+		groups[n.Zone][n.Group] = append(groups[n.Zone][n.Group], n.ID)
+	}
+	return groups
+}
@@ -0,0 +1,212 @@
+package topology
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ApogeeNetworking/ruckus"
+)
+
+var bridge = []ruckus.LldpCapability{{Name: "Bridge", Enabled: true}}
+
+func nodeIDs(g *Graph) []string {
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func TestBuild(t *testing.T) {
+	aps := []ruckus.RksAp{
+		{MacAddr: "AA:00:01", ApName: "ap1", ZoneName: "campus", GroupName: "north"},
+		{MacAddr: "AA:00:02", ApName: "ap2", ZoneName: "campus", GroupName: "south"},
+	}
+	neighbors := map[string][]ruckus.ApLldpNeighbor{
+		"AA:00:01": {
+			{LocalIntf: "eth0", ChassisID: "00:11:22:33:44:55", SysName: "sw1", MgmtIP: "10.0.0.2", PortID: "Gi1/0/1", Capabilities: bridge},
+			{LocalIntf: "eth1", SysName: "phone-7", PortID: "port 1"},
+		},
+		"AA:00:02": {
+			{LocalIntf: "eth0", ChassisID: "00:11:22:33:44:55", SysName: "sw1", MgmtIP: "10.0.0.2", PortID: "Gi1/0/2", Capabilities: bridge},
+		},
+	}
+	g := Build(aps, neighbors)
+
+	want := []string{
+		"ap:aa:00:01", "ap:aa:00:02",
+		"sw:00:11:22:33:44:55", "sw:00:11:22:33:44:55|Gi1/0/1", "sw:00:11:22:33:44:55|Gi1/0/2",
+		"sw:phone-7", "sw:phone-7|port 1",
+	}
+	if got := nodeIDs(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("nodes = %v; want %v", got, want)
+	}
+	kinds := make(map[string]NodeKind)
+	for _, n := range g.Nodes {
+		kinds[n.ID] = n.Kind
+	}
+	if kinds["sw:00:11:22:33:44:55"] != KindSwitch || kinds["sw:phone-7"] != KindDevice || kinds["sw:phone-7|port 1"] != KindPort {
+		t.Errorf("kinds = %v", kinds)
+	}
+	wantEdges := []Edge{
+		{Source: "sw:00:11:22:33:44:55", Target: "sw:00:11:22:33:44:55|Gi1/0/1"},
+		{Source: "sw:00:11:22:33:44:55", Target: "sw:00:11:22:33:44:55|Gi1/0/2"},
+		{Source: "sw:00:11:22:33:44:55|Gi1/0/1", Target: "ap:aa:00:01", LocalIntf: "eth0"},
+		{Source: "sw:00:11:22:33:44:55|Gi1/0/2", Target: "ap:aa:00:02", LocalIntf: "eth0"},
+		{Source: "sw:phone-7", Target: "sw:phone-7|port 1"},
+		{Source: "sw:phone-7|port 1", Target: "ap:aa:00:01", LocalIntf: "eth1"},
+	}
+	if !reflect.DeepEqual(g.Edges, wantEdges) {
+		t.Errorf("edges = %+v; want %+v", g.Edges, wantEdges)
+	}
+	if len(g.Warnings) != 0 {
+		t.Errorf("warnings = %v", g.Warnings)
+	}
+	groups := map[string]map[string][]string{"campus": {"north": {"ap:aa:00:01"}, "south": {"ap:aa:00:02"}}}
+	if !reflect.DeepEqual(g.Groups(), groups) {
+		t.Errorf("groups = %v; want %v", g.Groups(), groups)
+	}
+}
+
+func TestBuildNeighborsWithoutIdentifier(t *testing.T) {
+	aps := []ruckus.RksAp{{MacAddr: "aa:00:01"}, {MacAddr: "aa:00:02"}}
+	neighbors := map[string][]ruckus.ApLldpNeighbor{
+		"aa:00:01": {{LocalIntf: "eth0"}},
+		"aa:00:02": {{LocalIntf: "eth0"}, {}},
+	}
+	g := Build(aps, neighbors)
+
+	want := []string{
+		"ap:aa:00:01", "ap:aa:00:02",
+		"unknown:ap:aa:00:01/eth0", "unknown:ap:aa:00:01/eth0|ap:aa:00:01/eth0",
+		"unknown:ap:aa:00:02/1", "unknown:ap:aa:00:02/1|ap:aa:00:02/1",
+		"unknown:ap:aa:00:02/eth0", "unknown:ap:aa:00:02/eth0|ap:aa:00:02/eth0",
+	}
+	if got := nodeIDs(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("nodes = %v; want %v", got, want)
+	}
+	if len(g.Warnings) != 3 {
+		t.Errorf("warnings = %v; want one per unidentified neighbor", g.Warnings)
+	}
+}
+
+func TestBuildFlagsInconsistentSwitches(t *testing.T) {
+	aps := []ruckus.RksAp{{MacAddr: "aa:00:01"}, {MacAddr: "aa:00:02"}, {MacAddr: "aa:00:03"}}
+	neighbors := map[string][]ruckus.ApLldpNeighbor{
+		"aa:00:01": {{ChassisID: "c1", SysName: "sw1", MgmtIP: "10.0.0.2", PortID: "1"}},
+		"aa:00:02": {{ChassisID: "c2", SysName: "SW1", MgmtIP: "10.0.0.3", PortID: "1"}},
+		"aa:00:03": {{ChassisID: "c3", SysName: "sw3", MgmtIP: "10.0.0.9", PortID: "1"}},
+	}
+	g := Build(aps, neighbors)
+
+	flagged := make(map[string]bool)
+	for _, n := range g.Nodes {
+		if n.Inconsistent {
+			flagged[n.ID] = true
+		}
+	}
+	if !reflect.DeepEqual(flagged, map[string]bool{"sw:c1": true, "sw:c2": true}) {
+		t.Errorf("flagged = %v; want sw:c1 and sw:c2", flagged)
+	}
+	if len(g.Warnings) != 1 || !strings.Contains(g.Warnings[0], "sw1 seen with multiple IPs: 10.0.0.2, 10.0.0.3") {
+		t.Errorf("warnings = %v", g.Warnings)
+	}
+}
+
+func exampleGraph() *Graph {
+	return Build(
+		[]ruckus.RksAp{{MacAddr: "aa:00:01", ApName: `lobby "A"`, ZoneName: "campus", GroupName: "north"}},
+		map[string][]ruckus.ApLldpNeighbor{"aa:00:01": {
+			{LocalIntf: "eth0", ChassisID: "c1", SysName: "sw<1>", MgmtIP: "10.0.0.2", PortID: "Gi1/0/1", Capabilities: bridge},
+		}},
+	)
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := exampleGraph().WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`label="zone: campus";`,
+		`label="group: north";`,
+		`"ap:aa:00:01" [label="lobby \"A\"", shape=ellipse];`,
+		`"sw:c1" [label="sw<1>\n10.0.0.2", shape=box];`,
+		`"sw:c1|Gi1/0/1" -- "ap:aa:00:01" [label="eth0"];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT lacks %s\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := exampleGraph().WriteGraphML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid GraphML: %v\n%s", err, buf.String())
+	}
+	if len(doc.Graph.Nodes) != 3 || len(doc.Graph.Edges) != 2 {
+		t.Fatalf("%d nodes and %d edges; want 3 and 2", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+	for _, n := range doc.Graph.Nodes {
+		if n.ID != "sw:c1" {
+			continue
+		}
+		for _, d := range n.Data {
+			if d.Key == "label" && d.Value != "sw<1>" {
+				t.Errorf("label = %q", d.Value)
+			}
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Build(nil, nil).WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var empty map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &empty); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"nodes", "links", "warnings"} {
+		if l, ok := empty[k].([]interface{}); !ok || len(l) != 0 {
+			t.Errorf("%s = %v; want []", k, empty[k])
+		}
+	}
+
+	buf.Reset()
+	if err := exampleGraph().WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc nodeLink
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Nodes) != 3 || len(doc.Links) != 2 || !reflect.DeepEqual(doc.Groups["campus"]["north"], []string{"ap:aa:00:01"}) {
+		t.Errorf("document = %+v", doc)
+	}
+}
@@ -1,5 +1,10 @@
 package ruckus
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // Mapper ...
 type Mapper struct {
 	Type     string `json:"type"`
@@ -14,6 +19,21 @@ type RksCommonReq struct {
 	FirstIndex int  `json:"firstIndex"`
 }
 
+// RksError an Error Response returned by the Controller
+type RksError struct {
+	StatusCode int    `json:"-"`
+	ErrorCode  int    `json:"errorCode"`
+	ErrorType  string `json:"errorType"`
+	Message    string `json:"message"`
+}
+
+func (e *RksError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("controller returned %d", e.StatusCode)
+	}
+	return fmt.Sprintf("controller returned %d: %s", e.StatusCode, e.Message)
+}
+
 // RksOptions common ruckus query options for data Retrieval
 type RksOptions struct {
 	// optional: the index of the 1st Entry to be retrieved.
@@ -59,10 +79,34 @@ type RksController struct {
 	ControlIpv6  interface{} `json:"controlIpv6"`
 	ClusterIpv6  interface{} `json:"clusterIpv6"`
 	MgmtIpv6     interface{} `json:"managementIpv6"`
+	rawDoc
+}
+
+type rksController RksController
+
+// UnmarshalJSON decodes a Controller keeping the document (see MergeRaw)
+func (c *RksController) UnmarshalJSON(data []byte) error {
+	var v rksController
+	err := json.Unmarshal(data, &v)
+	v.keep(data)
+	*c = RksController(v)
+	return err
+}
+
+// MarshalJSON encodes a Controller merged into the document it was
+// retrieved as (see MergeRaw) so the fields it does not model are kept
+func (c RksController) MarshalJSON() ([]byte, error) {
+	typed, err := json.Marshal(rksController(c))
+	if err != nil {
+		return nil, err
+	}
+	return mergeRaw(c.Raw(), typed)
 }
 
 // RksWlan ...
 type RksWlan struct {
+	ID       string `json:"wlanId"`
+	ZoneID   string `json:"zoneId"`
 	Name     string `json:"name"`
 	SSID     string `json:"ssid"`
 	Client   int    `json:"clients"`
@@ -91,6 +135,34 @@ type RksAp struct {
 	ExtIPAddr  string `json:"extIp"`
 	Firmware   string `json:"firmwareVersion"`
 	PortStatus string `json:"poePortStatus"`
+	Clients    int    `json:"numClients"`
+	// the Channel each Radio operates on (0 when off) ie "36 (80MHz)"
+	Channel24     int    `json:"channel24gValue"`
+	Channel50     int    `json:"channel50gValue"`
+	Channel24Desc string `json:"channel24G"`
+	Channel50Desc string `json:"channel5G"`
+	rawDoc
+}
+
+type rksAp RksAp
+
+// UnmarshalJSON decodes an AP keeping the document (see MergeRaw)
+func (a *RksAp) UnmarshalJSON(data []byte) error {
+	var v rksAp
+	err := json.Unmarshal(data, &v)
+	v.keep(data)
+	*a = RksAp(v)
+	return err
+}
+
+// MarshalJSON encodes an AP merged into the document it was retrieved as
+// (see MergeRaw) so the fields it does not model are kept
+func (a RksAp) MarshalJSON() ([]byte, error) {
+	typed, err := json.Marshal(rksAp(a))
+	if err != nil {
+		return nil, err
+	}
+	return mergeRaw(a.Raw(), typed)
 }
 
 // ApIntf ...
@@ -107,3 +179,44 @@ type ApLldp struct {
 	RemoteIntf     string `json:"lldpPortID"`
 	RemoteIP       string `json:"lldpMgmtIP"`
 }
+
+// ApOperational operational summary of an AP
+type ApOperational struct {
+	ApName              string `json:"deviceName"`
+	Description         string `json:"description"`
+	Location            string `json:"location"`
+	ZoneName            string `json:"zoneName"`
+	GroupName           string `json:"apGroupName"`
+	AdministrativeState string `json:"administrativeState"`
+	RegistrationState   string `json:"registrationState"`
+	ConnectionState     string `json:"connectionState"`
+	ApprovedTime        int64  `json:"approvedTime"`
+	LastSeenTime        int64  `json:"lastSeenTime"`
+	UptimeInSec         int64  `json:"uptime"`
+	IPAddr              string `json:"ip"`
+	Ipv6Addr            string `json:"ipv6"`
+	ExtIPAddr           string `json:"externalIp"`
+	Model               string `json:"model"`
+	Serial              string `json:"serialNumber"`
+	Firmware            string `json:"firmwareVersion"`
+}
+
+// RksAlarm ruckus controller alarm properties
+type RksAlarm struct {
+	ID            string `json:"id"`
+	Severity      string `json:"severity"`
+	AlarmState    string `json:"alarmState"`
+	AlarmType     string `json:"alarmType"`
+	Category      string `json:"category"`
+	Activity      string `json:"activity"`
+	SourceName    string `json:"sourceName"`
+	InsertionTime int64  `json:"insertionTime"`
+}
+
+// FieldDiff a difference of a single field between two versions of a Resource
+// Path is the dotted JSON path of the field (ie "wifi24.txPower")
+type FieldDiff struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}